@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetActivityWeights handles the GET request for the activity scoring weights
+func (h *GroupHandler) GetActivityWeights(c *gin.Context) {
+	c.JSON(http.StatusOK, h.groupService.GetActivityWeights())
+}
+
+// SetActivityWeights handles the PUT request for changing the activity scoring weights
+func (h *GroupHandler) SetActivityWeights(c *gin.Context) {
+	var request models.ActivityWeightsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.SetActivityWeights(request.ActorID, request.ActivityWeights); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.groupService.GetActivityWeights())
+}
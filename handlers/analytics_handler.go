@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetGroupAnalytics handles the GET request for a group's activity figures over a time range
+func (h *GroupHandler) GetGroupAnalytics(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	analytics, err := h.groupService.GetGroupAnalytics(groupID, actorID, from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// parseTimeQuery reads an RFC 3339 time or a UTC date, returning the zero
+// time when the parameter is missing
+func parseTimeQuery(c *gin.Context, name string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("%s must be a date like 2026-10-01 or an RFC 3339 time", name)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PinMessage handles the PUT request for pinning a message to the top of a group
+func (h *GroupHandler) PinMessage(c *gin.Context) {
+	var request models.PinRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.PinMessage(c.Param("id"), c.Param("message_id"), request.ActorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group.PinnedMessages)
+}
+
+// UnpinMessage handles the DELETE request for unpinning a message
+func (h *GroupHandler) UnpinMessage(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	group, err := h.groupService.UnpinMessage(c.Param("id"), c.Param("message_id"), actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group.PinnedMessages)
+}
+
+// PostAnnouncement handles the POST request for a group announcement
+func (h *GroupHandler) PostAnnouncement(c *gin.Context) {
+	var request models.AnnouncementRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	announcement, err := h.groupService.PostAnnouncement(c.Param("id"), &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, announcement)
+}
+
+// DeleteAnnouncement handles the DELETE request for taking an announcement down
+func (h *GroupHandler) DeleteAnnouncement(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.groupService.DeleteAnnouncement(c.Param("id"), c.Param("announcement_id"), actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Announcement deleted"})
+}
+
+// AcknowledgeAnnouncement handles the POST request for a member marking an announcement as seen
+func (h *GroupHandler) AcknowledgeAnnouncement(c *gin.Context) {
+	announcement, err := h.groupService.AcknowledgeAnnouncement(c.Param("id"), c.Param("announcement_id"), c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, announcement)
+}
+
+// GetAnnouncementReceipts handles the GET request for who has seen an announcement
+func (h *GroupHandler) GetAnnouncementReceipts(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	receipts, err := h.groupService.GetAnnouncementReceipts(c.Param("id"), c.Param("announcement_id"), actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, receipts)
+}
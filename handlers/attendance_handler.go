@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"allen_hackathon/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAttendanceReport handles the GET request for a group's attendance report
+func (h *GroupHandler) GetAttendanceReport(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	report, err := h.groupService.GetAttendanceReport(groupID, actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportAttendanceReport handles the GET request for a group's attendance report as CSV
+func (h *GroupHandler) ExportAttendanceReport(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	report, err := h.groupService.GetAttendanceReport(groupID, actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	data, err := services.AttendanceReportCSV(report)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"group-%s-attendance.csv\"", groupID))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBreakout handles the POST request for splitting members off into a breakout
+func (h *GroupHandler) CreateBreakout(c *gin.Context) {
+	var request models.BreakoutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	breakout, err := h.groupService.CreateBreakout(c.Param("id"), &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, breakout)
+}
+
+// AutoBreakouts handles the POST request for splitting a group into breakouts of similar members
+func (h *GroupHandler) AutoBreakouts(c *gin.Context) {
+	var request models.AutoBreakoutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	breakouts, err := h.groupService.AutoBreakouts(c.Param("id"), &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, breakouts)
+}
+
+// CloseBreakouts handles the POST request for closing every open breakout of a group
+func (h *GroupHandler) CloseBreakouts(c *gin.Context) {
+	var request models.CloseBreakoutsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	breakouts, err := h.groupService.CloseBreakouts(c.Param("id"), request.ActorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakouts)
+}
+
+// GetBreakouts handles the GET request for a group's breakouts
+func (h *GroupHandler) GetBreakouts(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+		return
+	}
+
+	breakouts, err := h.groupService.GetBreakouts(c.Param("id"), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakouts)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"allen_hackathon/models"
+	"allen_hackathon/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	chatWriteWait  = 10 * time.Second
+	chatPongWait   = 60 * time.Second
+	chatPingPeriod = chatPongWait * 9 / 10
+	maxChatFrame   = 8 * 1024
+)
+
+var chatUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Same origins as the CORS middleware
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || origin == "http://localhost:3000"
+	},
+}
+
+// GroupChat upgrades to a WebSocket that streams the group's messages,
+// actions, membership changes and meeting status to a member, and takes
+// their messages in return. Every message frame is acknowledged with its ref.
+// The socket closes when the member leaves or is removed from the group.
+func (h *GroupHandler) GroupChat(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id query parameter is required"})
+		return
+	}
+
+	subscription, err := h.groupService.SubscribeToGroup(groupID, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer h.groupService.Unsubscribe(subscription)
+	// The socket stays open for as long as the member is around, so only
+	// each message takes the store lock
+	releaseStore(c)
+
+	// The upgrader writes its own error response
+	conn, err := chatUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	// done tells the writer the reader has stopped; writerDone tells the
+	// reader the writer has stopped, so neither can wait on the other forever
+	acks := make(chan models.ChatAck)
+	done := make(chan struct{})
+	writerDone := make(chan struct{})
+	defer close(done)
+	go writeChat(conn, subscription, acks, done, writerDone, userID)
+
+	conn.SetReadLimit(maxChatFrame)
+	conn.SetReadDeadline(time.Now().Add(chatPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(chatPongWait))
+	})
+	for {
+		var frame models.ChatFrame
+		if err := conn.ReadJSON(&frame); err != nil {
+			return
+		}
+
+		ack := models.ChatAck{Type: "ack", Ref: frame.Ref, OK: true}
+		if frame.Type != models.ChatFrameMessage {
+			ack.OK, ack.Error = false, "unknown frame type"
+		} else if err := services.Locked(func() error {
+			return h.groupService.SendMessage(groupID, userID, frame.Content)
+		}); err != nil {
+			ack.OK, ack.Error = false, err.Error()
+		}
+		select {
+		case acks <- ack:
+		case <-writerDone:
+			return
+		}
+	}
+}
+
+// writeChat is the only goroutine that writes to the socket. It closes the
+// socket when the subscription ends, the member leaves, or the reader stops.
+func writeChat(conn *websocket.Conn, subscription *services.Subscription, acks <-chan models.ChatAck, done <-chan struct{}, writerDone chan<- struct{}, userID string) {
+	defer close(writerDone)
+	ticker := time.NewTicker(chatPingPeriod)
+	defer ticker.Stop()
+	defer conn.Close()
+
+	closeWith := func(code int, text string) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(chatWriteWait))
+	}
+	for {
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				closeWith(websocket.CloseTryAgainLater, "too far behind")
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(chatWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+			if membership, ok := event.Data.(models.MembershipEvent); ok && event.Type == models.EventMemberLeft && membership.UserID == userID {
+				closeWith(websocket.ClosePolicyViolation, "no longer a member")
+				return
+			}
+		case ack := <-acks:
+			conn.SetWriteDeadline(time.Now().Add(chatWriteWait))
+			if err := conn.WriteJSON(ack); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(chatWriteWait)); err != nil {
+				return
+			}
+		case <-done:
+			closeWith(websocket.CloseNormalClosure, "")
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnswerQuestion handles the POST request for answering one of a group's questions
+func (h *GroupHandler) AnswerQuestion(c *gin.Context) {
+	groupID := c.Param("id")
+	questionID := c.Param("question_id")
+	if groupID == "" || questionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and question ID are required"})
+		return
+	}
+
+	var request models.AnswerRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	answer, err := h.groupService.AnswerQuestion(groupID, questionID, request.UserID, *request.Option)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, answer)
+}
+
+// GiveKudos handles the POST request for thanking a member for their help
+func (h *GroupHandler) GiveKudos(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	var request models.KudosRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	kudos, err := h.groupService.GiveKudos(groupID, userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, kudos)
+}
+
+// GetLeaderboard handles the GET request for a group's contribution leaderboard
+func (h *GroupHandler) GetLeaderboard(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	leaderboard, err := h.groupService.GetLeaderboard(groupID, c.Query("user_id"), c.Query("window"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+// OptOutOfLeaderboard handles the PUT request for hiding a member from the leaderboard
+func (h *GroupHandler) OptOutOfLeaderboard(c *gin.Context) {
+	h.setLeaderboardOptOut(c, true)
+}
+
+// OptInToLeaderboard handles the DELETE request for showing a member on the leaderboard again
+func (h *GroupHandler) OptInToLeaderboard(c *gin.Context) {
+	h.setLeaderboardOptOut(c, false)
+}
+
+func (h *GroupHandler) setLeaderboardOptOut(c *gin.Context, optOut bool) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	if err := h.groupService.SetLeaderboardOptOut(groupID, userID, optOut); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if optOut {
+		c.JSON(http.StatusOK, gin.H{"message": "Removed from the leaderboard"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Shown on the leaderboard"})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeepGroupOpen handles the POST request for a member objecting to their
+// dormant group being archived
+func (h *GroupHandler) KeepGroupOpen(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	if err := h.groupService.KeepGroupOpen(groupID, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Group will be kept open"})
+}
+
+// GetDormancyPolicy handles the GET request for the dormancy policy
+func (h *GroupHandler) GetDormancyPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, h.groupService.GetDormancyPolicy())
+}
+
+// SetDormancyPolicy handles the PUT request for changing the dormancy policy
+func (h *GroupHandler) SetDormancyPolicy(c *gin.Context) {
+	var request models.DormancySettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.SetDormancyPolicy(request.ActorID, request.DormancySettings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.groupService.GetDormancyPolicy())
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const feedHeartbeatPeriod = 15 * time.Second
+
+// StreamUserEvents streams the events of every group the user is in, and
+// changes to their recommendations, as Server-Sent Events. Reconnecting with
+// Last-Event-ID (or the last_event_id query parameter) replays what was
+// missed; a "resync" event means some of it is gone and the client should
+// reload. A "heartbeat" event is sent when the stream is otherwise quiet.
+func (h *GroupHandler) StreamUserEvents(c *gin.Context) {
+	userID := c.Param("user_id")
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	// IDs from before a restart cannot be resumed from, so those clients
+	// start from now and are told to reload
+	var since int64
+	restarted := false
+	if lastEventID != "" {
+		id, current, err := h.groupService.ParseEventStreamID(lastEventID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID must be an event ID"})
+			return
+		}
+		since, restarted = id, !current
+	}
+
+	replay, subscription, complete, err := h.groupService.FollowUser(userID, since)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer h.groupService.Unsubscribe(subscription)
+	// Events are filtered by whoever publishes them, under their own lock
+	releaseStore(c)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(event sse.Event) bool {
+		if err := sse.Encode(c.Writer, event); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+	sendEvent := func(event models.Event) bool {
+		return send(sse.Event{Id: h.groupService.EventStreamID(event), Event: event.Type, Data: event})
+	}
+
+	if (restarted || !complete) && !send(sse.Event{Event: "resync", Data: gin.H{"lastEventId": lastEventID}}) {
+		return
+	}
+	for _, event := range replay {
+		if !sendEvent(event) {
+			return
+		}
+	}
+	// Let the client know the stream is live even if nothing has happened
+	if !send(sse.Event{Event: "heartbeat", Data: gin.H{"timestamp": time.Now()}}) {
+		return
+	}
+
+	heartbeat := time.NewTicker(feedHeartbeatPeriod)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// Dropped for falling behind; the client reconnects and resumes
+				return
+			}
+			if !sendEvent(event) {
+				return
+			}
+			heartbeat.Reset(feedHeartbeatPeriod)
+		case now := <-heartbeat.C:
+			if !send(sse.Event{Event: "heartbeat", Data: gin.H{"timestamp": now}}) {
+				return
+			}
+		}
+	}
+}
@@ -23,20 +23,35 @@ func NewGroupHandler(groupService *services.GroupService, store *storage.MemoryS
 }
 
 func (h *GroupHandler) CreateGroup(c *gin.Context) {
-	var group models.Group
-	if err := c.ShouldBindJSON(&group); err != nil {
+	// Private is a pointer so groups that leave it out get their type's default
+	var request struct {
+		models.Group
+		Private *bool `json:"private"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
 
+	group := request.Group
+	if request.Private != nil {
+		group.Private = *request.Private
+	} else if groupType := h.groupService.GroupType(group.Type); groupType != nil {
+		group.Private = groupType.DefaultPrivate
+	}
+
 	if err := h.groupService.CreateGroup(&group); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(201, group)
 }
 
+func (h *GroupHandler) GetGroupTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, h.groupService.GroupTypes())
+}
+
 func (h *GroupHandler) SearchGroupsByTag(c *gin.Context) {
 	var request struct {
 		Tag string `json:"tag" binding:"required"`
@@ -48,10 +63,60 @@ func (h *GroupHandler) SearchGroupsByTag(c *gin.Context) {
 		return
 	}
 
-	groups := h.store.SearchGroupsByTag(request.Tag, request.UserID)
+	options, err := bindGroupListOptions(c, "cursor")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Clients that do not page still get the plain array this endpoint
+	// has always returned
+	if !pagingRequested(c, "cursor") {
+		groups, err := h.groupService.SearchAllGroupsByTag(request.Tag, request.UserID, options)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, groups)
+		return
+	}
+
+	groups, err := h.groupService.SearchGroupsByTag(request.Tag, request.UserID, options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, groups)
 }
 
+// SearchGroups handles the GET request for ranked full-text search over group titles, descriptions and tags
+func (h *GroupHandler) SearchGroups(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q query parameter is required"})
+		return
+	}
+
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id query parameter is required"})
+		return
+	}
+
+	options, err := bindGroupListOptions(c, "cursor")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := h.groupService.SearchGroups(query, userID, options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
 func (h *GroupHandler) GetGroupsPage(c *gin.Context) {
 	userID := c.Param("user_id")
 	if userID == "" {
@@ -59,7 +124,21 @@ func (h *GroupHandler) GetGroupsPage(c *gin.Context) {
 		return
 	}
 
-	groupsPage, err := h.groupService.GetGroupsPage(userID)
+	activeOptions, err := bindGroupListOptions(c, "active_cursor")
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	recommendedOptions, err := bindGroupListOptions(c, "recommended_cursor")
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	// Lists that are not being paged come back whole, as they always have
+	activeOptions.All = !pagingRequested(c, "active_cursor")
+	recommendedOptions.All = !pagingRequested(c, "recommended_cursor")
+
+	groupsPage, err := h.groupService.GetGroupsPage(userID, activeOptions, recommendedOptions)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -104,11 +183,22 @@ func (h *GroupHandler) JoinGroup(c *gin.Context) {
 		return
 	}
 
-	if err := h.groupService.JoinGroup(groupID, userID); err != nil {
+	result, err := h.groupService.RequestJoin(groupID, userID)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if result.Status == models.JoinStatusPending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "Join request sent for approval", "request": result.Request})
+		return
+	}
+
+	if result.Status == models.JoinStatusWaitlisted {
+		c.JSON(http.StatusAccepted, gin.H{"message": "Group is full, added to the waitlist", "waitlist": result.Waitlist})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully joined the group"})
 }
 
@@ -147,14 +237,19 @@ func (h *GroupHandler) UpdateGroup(c *gin.Context) {
 		return
 	}
 
-	// Validate that exactly one of message or action is provided
-	if (update.Message == nil && update.Action == nil) || (update.Message != nil && update.Action != nil) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of message or action must be provided"})
+	// Validate that at most one of message or action is provided, and that
+	// there is something to do
+	if update.Message != nil && update.Action != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only one of message or action can be provided"})
+		return
+	}
+	if update.Message == nil && update.Action == nil && update.MeetingStarted == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "one of message, action or meetingStarted must be provided"})
 		return
 	}
 
 	if err := h.groupService.UpdateGroup(groupID, &update); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
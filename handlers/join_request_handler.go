@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJoinRequests handles the GET request for listing a group's join requests
+func (h *GroupHandler) GetJoinRequests(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	requests, err := h.groupService.GetJoinRequests(groupID, actorID, c.Query("status"), page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// GetUserJoinRequests handles the GET request for a user to see the status of their join requests
+func (h *GroupHandler) GetUserJoinRequests(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	requests, err := h.groupService.GetUserJoinRequests(userID, page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// ApproveJoinRequest handles the POST request for approving a pending join request
+func (h *GroupHandler) ApproveJoinRequest(c *gin.Context) {
+	h.decideJoinRequest(c, h.groupService.ApproveJoinRequest)
+}
+
+// RejectJoinRequest handles the POST request for rejecting a pending join request
+func (h *GroupHandler) RejectJoinRequest(c *gin.Context) {
+	h.decideJoinRequest(c, h.groupService.RejectJoinRequest)
+}
+
+func (h *GroupHandler) decideJoinRequest(c *gin.Context, decide func(groupID, requestID, actorID string) (*models.JoinRequest, error)) {
+	groupID := c.Param("id")
+	requestID := c.Param("request_id")
+	if groupID == "" || requestID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and request ID are required"})
+		return
+	}
+
+	var decision models.JoinRequestDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := decide(groupID, requestID, decision.ActorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// AddModerator handles the POST request for the owner to appoint a moderator
+func (h *GroupHandler) AddModerator(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	var request models.ModeratorRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.AddModerator(groupID, userID, request.ActorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Moderator added successfully"})
+}
+
+// RemoveModerator handles the DELETE request for the owner to remove a moderator
+func (h *GroupHandler) RemoveModerator(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.groupService.RemoveModerator(groupID, userID, actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Moderator removed successfully"})
+}
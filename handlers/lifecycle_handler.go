@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEmptyGroupPolicy handles the GET request for the empty-group policy
+func (h *GroupHandler) GetEmptyGroupPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, h.groupService.GetEmptyGroupPolicy())
+}
+
+// SetEmptyGroupPolicy handles the PUT request for changing the empty-group policy
+func (h *GroupHandler) SetEmptyGroupPolicy(c *gin.Context) {
+	var request models.EmptyGroupSettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.SetEmptyGroupPolicy(request.ActorID, request.EmptyGroupSettings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.groupService.GetEmptyGroupPolicy())
+}
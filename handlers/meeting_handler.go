@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartMeeting handles the POST request for opening a group's meeting
+func (h *GroupHandler) StartMeeting(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	var request models.MeetingStartRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meeting, err := h.groupService.StartMeeting(groupID, request.UserID, request.SessionID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, meeting)
+}
+
+// JoinMeeting handles the POST request for joining a group's open meeting
+func (h *GroupHandler) JoinMeeting(c *gin.Context) {
+	h.meetingAction(c, h.groupService.JoinMeeting)
+}
+
+// LeaveMeeting handles the POST request for leaving a group's open meeting
+func (h *GroupHandler) LeaveMeeting(c *gin.Context) {
+	h.meetingAction(c, h.groupService.LeaveMeeting)
+}
+
+// EndMeeting handles the POST request for closing a group's open meeting
+func (h *GroupHandler) EndMeeting(c *gin.Context) {
+	h.meetingAction(c, h.groupService.EndMeeting)
+}
+
+func (h *GroupHandler) meetingAction(c *gin.Context, action func(groupID string, userID string) (*models.Meeting, error)) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	var request models.MeetingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meeting, err := action(groupID, request.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, meeting)
+}
+
+// GetCurrentMeeting handles the GET request for a group's open meeting
+func (h *GroupHandler) GetCurrentMeeting(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	meeting, err := h.groupService.GetCurrentMeeting(groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if meeting == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no meeting is in progress"})
+		return
+	}
+
+	c.JSON(http.StatusOK, meeting)
+}
+
+// GetMeetings handles the GET request for a group's meeting history
+func (h *GroupHandler) GetMeetings(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meetings, err := h.groupService.GetMeetings(groupID, c.Query("user_id"), page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, meetings)
+}
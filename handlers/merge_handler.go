@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MergeGroups handles the POST request for merging groups into another (admin only)
+func (h *GroupHandler) MergeGroups(c *gin.Context) {
+	var request models.GroupMergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.groupService.MergeGroups(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMergeSuggestions handles the GET request for groups worth merging (admin only)
+func (h *GroupHandler) GetMergeSuggestions(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor ID is required"})
+		return
+	}
+
+	suggestions, err := h.groupService.SuggestGroupMerges(actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// FollowMergedGroups permanently redirects requests for a merged group to the
+// group it was merged into, keeping the method, body and query
+func (h *GroupHandler) FollowMergedGroups(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.Next()
+		return
+	}
+
+	targetID, err := h.groupService.MergedGroupID(groupID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if targetID == groupID {
+		c.Next()
+		return
+	}
+
+	location := *c.Request.URL
+	location.Path = strings.Replace(location.Path, "/"+groupID, "/"+targetID, 1)
+	c.Redirect(http.StatusPermanentRedirect, location.String())
+	c.Abort()
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KickMember handles the POST request for removing a member from a group
+func (h *GroupHandler) KickMember(c *gin.Context) {
+	var request models.ModerationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.KickMember(c.Param("id"), c.Param("user_id"), &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// BanMember handles the POST request for banning a user from a group
+func (h *GroupHandler) BanMember(c *gin.Context) {
+	var request models.ModerationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.BanMember(c.Param("id"), c.Param("user_id"), &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User banned"})
+}
+
+// UnbanMember handles the DELETE request for lifting a ban
+func (h *GroupHandler) UnbanMember(c *gin.Context) {
+	request, ok := bindModerationQuery(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groupService.UnbanMember(c.Param("id"), c.Param("user_id"), request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ban lifted"})
+}
+
+// TimeoutMember handles the POST request for putting a member in read-only mode
+func (h *GroupHandler) TimeoutMember(c *gin.Context) {
+	var request models.ModerationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	until, err := h.groupService.TimeoutMember(c.Param("id"), c.Param("user_id"), &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member timed out", "until": until})
+}
+
+// EndTimeout handles the DELETE request for ending a member's timeout early
+func (h *GroupHandler) EndTimeout(c *gin.Context) {
+	request, ok := bindModerationQuery(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groupService.EndTimeout(c.Param("id"), c.Param("user_id"), request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Timeout ended"})
+}
+
+// GetModerationState handles the GET request for a group's bans and timeouts
+func (h *GroupHandler) GetModerationState(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	state, err := h.groupService.GetModerationState(c.Param("id"), actorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// GetModerationActions handles the GET request for the moderation audit trail (admin only)
+func (h *GroupHandler) GetModerationActions(c *gin.Context) {
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actions, err := h.groupService.GetModerationActions(actorID, c.Query("group_id"), c.Query("user_id"), page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, actions)
+}
+
+// bindModerationQuery reads the actor and reason of a DELETE moderation request
+func bindModerationQuery(c *gin.Context) (*models.ModerationRequest, bool) {
+	request := &models.ModerationRequest{
+		ActorID: c.Query("actor_id"),
+		Reason:  c.Query("reason"),
+	}
+	if request.ActorID == "" || request.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id and reason query parameters are required"})
+		return nil, false
+	}
+	return request, true
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferOwnership handles the POST request for the owner to hand a group over to another member
+func (h *GroupHandler) TransferOwnership(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	var request models.OwnershipTransferRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.groupService.TransferOwnership(groupID, userID, request.ActorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ownership transferred successfully"})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindPageRequest reads the limit and cursor query parameters
+func bindPageRequest(c *gin.Context) (models.PageRequest, error) {
+	return bindPageRequestWithCursor(c, "cursor")
+}
+
+func bindPageRequestWithCursor(c *gin.Context, cursorParam string) (models.PageRequest, error) {
+	request := models.PageRequest{Cursor: c.Query(cursorParam)}
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			return request, fmt.Errorf("limit must be a positive number")
+		}
+		request.Limit = value
+	}
+	return request, nil
+}
+
+// pagingRequested reports whether the client asked for a page rather than
+// the whole list
+func pagingRequested(c *gin.Context, cursorParam string) bool {
+	return c.Query(cursorParam) != "" || c.Query("limit") != ""
+}
+
+// bindGroupListOptions reads the paging, sort and filter query parameters for group lists
+func bindGroupListOptions(c *gin.Context, cursorParam string) (models.GroupListOptions, error) {
+	page, err := bindPageRequestWithCursor(c, cursorParam)
+	if err != nil {
+		return models.GroupListOptions{}, err
+	}
+
+	options := models.GroupListOptions{
+		PageRequest: page,
+		Sort:        c.Query("sort"),
+		Type:        c.Query("type"),
+		CreatedBy:   c.Query("created_by"),
+	}
+	if private := c.Query("private"); private != "" {
+		value, err := strconv.ParseBool(private)
+		if err != nil {
+			return options, fmt.Errorf("private must be true or false")
+		}
+		options.Private = &value
+	}
+	if minFreeSeats := c.Query("min_free_seats"); minFreeSeats != "" {
+		value, err := strconv.Atoi(minFreeSeats)
+		if err != nil || value < 0 {
+			return options, fmt.Errorf("min_free_seats must be a non-negative number")
+		}
+		options.MinFreeSeats = value
+	}
+	return options, nil
+}
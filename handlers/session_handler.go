@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// ScheduleSession handles the POST request for adding a session to a group's calendar
+func (h *GroupHandler) ScheduleSession(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	var request models.SessionCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.groupService.ScheduleSession(groupID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetGroupSessions handles the GET request for listing a group's sessions
+func (h *GroupHandler) GetGroupSessions(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessions, err := h.groupService.GetGroupSessions(groupID, c.Query("user_id"), page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// GetSession handles the GET request for a session with its upcoming occurrences and RSVPs
+func (h *GroupHandler) GetSession(c *gin.Context) {
+	groupID := c.Param("id")
+	sessionID := c.Param("session_id")
+	if groupID == "" || sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and session ID are required"})
+		return
+	}
+
+	details, err := h.groupService.GetSession(groupID, sessionID, c.Query("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if details == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// CancelSession handles the DELETE request for removing a session
+func (h *GroupHandler) CancelSession(c *gin.Context) {
+	groupID := c.Param("id")
+	sessionID := c.Param("session_id")
+	if groupID == "" || sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and session ID are required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.groupService.CancelSession(groupID, sessionID, actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session cancelled"})
+}
+
+// RSVPSession handles the PUT request for a member's answer to a session
+func (h *GroupHandler) RSVPSession(c *gin.Context) {
+	groupID := c.Param("id")
+	sessionID := c.Param("session_id")
+	userID := c.Param("user_id")
+	if groupID == "" || sessionID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID, session ID and user ID are required"})
+		return
+	}
+
+	var request models.SessionRSVPRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsvp, err := h.groupService.RSVPSession(groupID, sessionID, userID, request.Response)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rsvp)
+}
+
+// GetGroupCalendar handles the GET request for a group's iCalendar feed
+func (h *GroupHandler) GetGroupCalendar(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	calendar, err := h.groupService.GroupCalendar(groupID, c.Query("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"group-%s.ics\"", groupID))
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
+// GetUserCalendar handles the GET request for the iCalendar feed of all a user's groups
+func (h *GroupHandler) GetUserCalendar(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+		return
+	}
+
+	calendar, err := h.groupService.UserCalendar(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"user-%s.ics\"", userID))
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"allen_hackathon/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const storeLockedKey = "storeLocked"
+
+// LockStore holds the store lock for the whole request, so requests never
+// see background jobs or each other half way through a change. Streaming
+// handlers release it once they are set up and lock around each call after.
+func LockStore(c *gin.Context) {
+	services.LockStore()
+	c.Set(storeLockedKey, true)
+	defer releaseStore(c)
+	c.Next()
+}
+
+// releaseStore gives up the request's store lock if it still holds it
+func releaseStore(c *gin.Context) {
+	if c.GetBool(storeLockedKey) {
+		c.Set(storeLockedKey, false)
+		services.UnlockStore()
+	}
+}
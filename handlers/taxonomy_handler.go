@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+	"allen_hackathon/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxonomyHandler struct {
+	taxonomyService *services.TaxonomyService
+}
+
+func NewTaxonomyHandler(taxonomyService *services.TaxonomyService) *TaxonomyHandler {
+	return &TaxonomyHandler{
+		taxonomyService: taxonomyService,
+	}
+}
+
+// GetTaxonomy handles the GET request for the subject/chapter/topic tree
+func (h *TaxonomyHandler) GetTaxonomy(c *gin.Context) {
+	tree, err := h.taxonomyService.GetTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// CreateTaxonomyNode handles the POST request for adding a subject, chapter or topic
+func (h *TaxonomyHandler) CreateTaxonomyNode(c *gin.Context) {
+	var request models.TaxonomyNodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, err := h.taxonomyService.CreateNode(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, node)
+}
+
+// DeleteTaxonomyNode handles the DELETE request for removing an unused leaf node
+func (h *TaxonomyHandler) DeleteTaxonomyNode(c *gin.Context) {
+	nodeID := c.Param("node_id")
+	if nodeID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "node ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.taxonomyService.DeleteNode(nodeID, actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Taxonomy node deleted successfully"})
+}
+
+// GetTagSynonyms handles the GET request for the tag synonym dictionary
+func (h *TaxonomyHandler) GetTagSynonyms(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	synonyms, err := h.taxonomyService.GetSynonyms(page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, synonyms)
+}
+
+// SetTagSynonym handles the PUT request for adding or changing a tag synonym
+func (h *TaxonomyHandler) SetTagSynonym(c *gin.Context) {
+	var request models.TagSynonymRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	synonym, err := h.taxonomyService.SetSynonym(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, synonym)
+}
+
+// DeleteTagSynonym handles the DELETE request for removing a tag synonym
+func (h *TaxonomyHandler) DeleteTagSynonym(c *gin.Context) {
+	alias := c.Param("alias")
+	if alias == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "alias is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.taxonomyService.DeleteSynonym(alias, actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag synonym deleted successfully"})
+}
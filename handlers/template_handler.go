@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+
+	"allen_hackathon/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTemplate handles the POST request for saving a group template
+func (h *GroupHandler) CreateTemplate(c *gin.Context) {
+	var request models.GroupTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.groupService.CreateTemplate(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplates handles the GET request for listing group templates
+func (h *GroupHandler) GetTemplates(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	templates, err := h.groupService.GetTemplates(page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetTemplate handles the GET request for a single group template
+func (h *GroupHandler) GetTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+	if templateID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template ID is required"})
+		return
+	}
+
+	template, err := h.groupService.GetTemplate(templateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if template == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles the DELETE request for removing a group template
+func (h *GroupHandler) DeleteTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+	if templateID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	if err := h.groupService.DeleteTemplate(templateID, actorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted"})
+}
+
+// InstantiateTemplate handles the POST request for creating a group from a template
+func (h *GroupHandler) InstantiateTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+	if templateID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template ID is required"})
+		return
+	}
+
+	var request models.TemplateInstantiateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.InstantiateTemplate(templateID, request.UserID, request.Params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// BulkInstantiateTemplate handles the POST request for creating one group per batch from a template
+func (h *GroupHandler) BulkInstantiateTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+	if templateID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template ID is required"})
+		return
+	}
+
+	var request models.TemplateBulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.groupService.BulkInstantiateTemplate(templateID, request.UserID, request.Batches)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Some groups were made and some were not
+	status := http.StatusCreated
+	if len(result.Failed) > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, result)
+}
+
+// CloneGroup handles the POST request for copying a group's configuration into a new group
+func (h *GroupHandler) CloneGroup(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	var request models.CloneGroupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.CloneGroup(groupID, request.UserID, request.Title)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWaitlist handles the GET request for listing a group's waitlist
+func (h *GroupHandler) GetWaitlist(c *gin.Context) {
+	groupID := c.Param("id")
+	if groupID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+		return
+	}
+
+	actorID := c.Query("actor_id")
+	if actorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id query parameter is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.groupService.GetWaitlist(groupID, actorID, page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetWaitlistPosition handles the GET request for a user's place in a group's queue
+func (h *GroupHandler) GetWaitlistPosition(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	position, err := h.groupService.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if position.Entry == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user is not on the waitlist for this group"})
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// GetUserWaitlists handles the GET request for every queue a user is in
+func (h *GroupHandler) GetUserWaitlists(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+		return
+	}
+
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	positions, err := h.groupService.GetUserWaitlists(userID, page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, positions)
+}
+
+// ConfirmWaitlistSeat handles the POST request for confirming a seat offered from the waitlist
+func (h *GroupHandler) ConfirmWaitlistSeat(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	entry, err := h.groupService.ConfirmWaitlistSeat(groupID, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// LeaveWaitlist handles the DELETE request for leaving a group's waitlist
+func (h *GroupHandler) LeaveWaitlist(c *gin.Context) {
+	groupID := c.Param("id")
+	userID := c.Param("user_id")
+	if groupID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group ID and user ID are required"})
+		return
+	}
+
+	if err := h.groupService.LeaveWaitlist(groupID, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully left the waitlist"})
+}
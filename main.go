@@ -4,6 +4,8 @@ import (
 	"allen_hackathon/handlers"
 	"allen_hackathon/services"
 	"allen_hackathon/storage"
+	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,16 +18,33 @@ func main() {
 
 	// Initialize services
 	groupService := services.NewGroupService(store)
+	userService := services.NewUserService(store)
+	taxonomyService := services.NewTaxonomyService(store)
 
 	// Initialize handlers
 	groupHandler := handlers.NewGroupHandler(groupService, store)
+	userHandler := handlers.NewUserHandler(userService)
+	taxonomyHandler := handlers.NewTaxonomyHandler(taxonomyService)
+
+	// Seed groups start out with scores worked out from their own activity
+	if err := groupService.RecomputeActivityScores(); err != nil {
+		log.Fatalf("failed to compute activity scores: %v", err)
+	}
+
+	// Background jobs
+	services.StartJob("waitlist-expiry", time.Minute, groupService.ExpireWaitlistAdmissions)
+	services.StartJob("empty-groups", time.Hour, groupService.ApplyEmptyGroupPolicy)
+	services.StartJob("expired-groups", time.Hour, groupService.ArchiveExpiredGroups)
+	services.StartJob("activity-scores", 15*time.Minute, groupService.RecomputeActivityScores)
+	services.StartJob("dormant-groups", time.Hour, groupService.ApplyDormancyPolicy)
+	services.StartJob("expired-announcements", time.Minute, groupService.ExpireAnnouncements)
 
 	// CORS middleware
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -34,19 +53,127 @@ func main() {
 
 		c.Next()
 	})
+	r.Use(handlers.LockStore)
 	// Group routes
 	api := r.Group("/api")
 	{
 		groups := api.Group("/groups")
 		{
+			groups.Use(groupHandler.FollowMergedGroups)
 			groups.POST("", groupHandler.CreateGroup)
 			groups.GET("/user/:user_id", groupHandler.GetGroupsPage)
 			groups.GET("/:id", groupHandler.GetGroup)
 			groups.POST("/:id/join/:user_id", groupHandler.JoinGroup)
 			groups.PUT("/:id", groupHandler.UpdateGroup)
+			groups.PATCH("/:id", groupHandler.PatchGroup)
+			groups.GET("/:id/history", groupHandler.GetGroupHistory)
 			groups.POST("/:id/leave/:user_id", groupHandler.LeaveGroup)
 			groups.POST("/search", groupHandler.SearchGroupsByTag)
+			groups.GET("/search", groupHandler.SearchGroups)
 			groups.POST("/:id/reject/:user_id", groupHandler.RejectGroupRecommendation)
+			groups.POST("/:id/invites", groupHandler.CreateInvite)
+			groups.GET("/:id/invites", groupHandler.GetGroupInvites)
+			groups.DELETE("/:id/invites/:invite_id", groupHandler.RevokeInvite)
+			groups.GET("/:id/join-requests", groupHandler.GetJoinRequests)
+			groups.POST("/:id/join-requests/:request_id/approve", groupHandler.ApproveJoinRequest)
+			groups.POST("/:id/join-requests/:request_id/reject", groupHandler.RejectJoinRequest)
+			groups.GET("/join-requests/user/:user_id", groupHandler.GetUserJoinRequests)
+			groups.POST("/:id/moderators/:user_id", groupHandler.AddModerator)
+			groups.DELETE("/:id/moderators/:user_id", groupHandler.RemoveModerator)
+			groups.POST("/:id/transfer/:user_id", groupHandler.TransferOwnership)
+			groups.GET("/:id/waitlist", groupHandler.GetWaitlist)
+			groups.GET("/:id/waitlist/:user_id", groupHandler.GetWaitlistPosition)
+			groups.POST("/:id/waitlist/:user_id/confirm", groupHandler.ConfirmWaitlistSeat)
+			groups.DELETE("/:id/waitlist/:user_id", groupHandler.LeaveWaitlist)
+			groups.GET("/waitlist/user/:user_id", groupHandler.GetUserWaitlists)
+			groups.POST("/:id/sessions", groupHandler.ScheduleSession)
+			groups.GET("/:id/sessions", groupHandler.GetGroupSessions)
+			groups.GET("/:id/sessions/:session_id", groupHandler.GetSession)
+			groups.DELETE("/:id/sessions/:session_id", groupHandler.CancelSession)
+			groups.PUT("/:id/sessions/:session_id/rsvp/:user_id", groupHandler.RSVPSession)
+			groups.GET("/:id/calendar.ics", groupHandler.GetGroupCalendar)
+			groups.POST("/:id/meeting/start", groupHandler.StartMeeting)
+			groups.POST("/:id/meeting/join", groupHandler.JoinMeeting)
+			groups.POST("/:id/meeting/leave", groupHandler.LeaveMeeting)
+			groups.POST("/:id/meeting/end", groupHandler.EndMeeting)
+			groups.GET("/:id/meeting", groupHandler.GetCurrentMeeting)
+			groups.GET("/:id/meetings", groupHandler.GetMeetings)
+			groups.GET("/:id/attendance", groupHandler.GetAttendanceReport)
+			groups.GET("/:id/attendance.csv", groupHandler.ExportAttendanceReport)
+			groups.GET("/:id/analytics", groupHandler.GetGroupAnalytics)
+			groups.POST("/:id/clone", groupHandler.CloneGroup)
+			groups.POST("/:id/questions/:question_id/answers", groupHandler.AnswerQuestion)
+			groups.POST("/:id/kudos/:user_id", groupHandler.GiveKudos)
+			groups.GET("/:id/leaderboard", groupHandler.GetLeaderboard)
+			groups.PUT("/:id/leaderboard/opt-out/:user_id", groupHandler.OptOutOfLeaderboard)
+			groups.DELETE("/:id/leaderboard/opt-out/:user_id", groupHandler.OptInToLeaderboard)
+			groups.POST("/:id/keep-open/:user_id", groupHandler.KeepGroupOpen)
+			groups.POST("/:id/breakouts", groupHandler.CreateBreakout)
+			groups.POST("/:id/breakouts/auto", groupHandler.AutoBreakouts)
+			groups.POST("/:id/breakouts/close", groupHandler.CloseBreakouts)
+			groups.GET("/:id/breakouts", groupHandler.GetBreakouts)
+			groups.PUT("/:id/pins/:message_id", groupHandler.PinMessage)
+			groups.DELETE("/:id/pins/:message_id", groupHandler.UnpinMessage)
+			groups.POST("/:id/announcements", groupHandler.PostAnnouncement)
+			groups.DELETE("/:id/announcements/:announcement_id", groupHandler.DeleteAnnouncement)
+			groups.POST("/:id/announcements/:announcement_id/ack/:user_id", groupHandler.AcknowledgeAnnouncement)
+			groups.GET("/:id/announcements/:announcement_id/receipts", groupHandler.GetAnnouncementReceipts)
+			groups.POST("/:id/kick/:user_id", groupHandler.KickMember)
+			groups.POST("/:id/bans/:user_id", groupHandler.BanMember)
+			groups.DELETE("/:id/bans/:user_id", groupHandler.UnbanMember)
+			groups.POST("/:id/timeouts/:user_id", groupHandler.TimeoutMember)
+			groups.DELETE("/:id/timeouts/:user_id", groupHandler.EndTimeout)
+			groups.GET("/:id/moderation", groupHandler.GetModerationState)
+			groups.GET("/:id/ws", groupHandler.GroupChat)
+		}
+
+		templates := api.Group("/templates")
+		{
+			templates.POST("", groupHandler.CreateTemplate)
+			templates.GET("", groupHandler.GetTemplates)
+			templates.GET("/:template_id", groupHandler.GetTemplate)
+			templates.DELETE("/:template_id", groupHandler.DeleteTemplate)
+			templates.POST("/:template_id/groups", groupHandler.InstantiateTemplate)
+			templates.POST("/:template_id/groups/bulk", groupHandler.BulkInstantiateTemplate)
+		}
+
+		invites := api.Group("/invites")
+		{
+			invites.GET("/user/:user_id", groupHandler.GetUserInvites)
+			invites.POST("/:code/redeem/:user_id", groupHandler.RedeemInvite)
+		}
+
+		api.GET("/group-types", groupHandler.GetGroupTypes)
+
+		taxonomy := api.Group("/taxonomy")
+		{
+			taxonomy.GET("", taxonomyHandler.GetTaxonomy)
+			taxonomy.POST("", taxonomyHandler.CreateTaxonomyNode)
+			taxonomy.DELETE("/:node_id", taxonomyHandler.DeleteTaxonomyNode)
+		}
+
+		admin := api.Group("/admin")
+		{
+			admin.GET("/tag-synonyms", taxonomyHandler.GetTagSynonyms)
+			admin.PUT("/tag-synonyms", taxonomyHandler.SetTagSynonym)
+			admin.DELETE("/tag-synonyms/:alias", taxonomyHandler.DeleteTagSynonym)
+			admin.GET("/activity-weights", groupHandler.GetActivityWeights)
+			admin.PUT("/activity-weights", groupHandler.SetActivityWeights)
+			admin.GET("/empty-group-policy", groupHandler.GetEmptyGroupPolicy)
+			admin.PUT("/empty-group-policy", groupHandler.SetEmptyGroupPolicy)
+			admin.GET("/dormancy-policy", groupHandler.GetDormancyPolicy)
+			admin.PUT("/dormancy-policy", groupHandler.SetDormancyPolicy)
+			admin.POST("/group-merges", groupHandler.MergeGroups)
+			admin.GET("/group-merges/suggestions", groupHandler.GetMergeSuggestions)
+			admin.GET("/moderation-actions", groupHandler.GetModerationActions)
+		}
+
+		users := api.Group("/users")
+		{
+			users.GET("/:user_id/profile", userHandler.GetUserProfile)
+			users.GET("/:user_id/export", userHandler.ExportUserData)
+			users.GET("/:user_id/calendar.ics", groupHandler.GetUserCalendar)
+			users.GET("/:user_id/events", groupHandler.StreamUserEvents)
 		}
 	}
 
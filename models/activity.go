@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// MembershipEvent records a user joining or leaving a group
+type MembershipEvent struct {
+	GroupID   string    `json:"groupId"`
+	UserID    string    `json:"userId"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+const (
+	MembershipJoined = "JOINED"
+	MembershipLeft   = "LEFT"
+)
+
+// ActivityWeights sets how much each kind of recent activity adds to a
+// group's activity score. An event's weight halves every HalfLifeHours.
+type ActivityWeights struct {
+	Message       float64 `json:"message"`
+	Action        float64 `json:"action"`
+	Meeting       float64 `json:"meeting"`
+	Join          float64 `json:"join"`
+	ActiveMember  float64 `json:"activeMember"`
+	HalfLifeHours float64 `json:"halfLifeHours"`
+}
+
+type ActivityWeightsRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+	ActivityWeights
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// GroupAnalytics sums up a group's activity between From and To. Days are
+// UTC calendar days.
+type GroupAnalytics struct {
+	GroupID               string         `json:"groupId"`
+	From                  time.Time      `json:"from"`
+	To                    time.Time      `json:"to"`
+	TotalMessages         int            `json:"totalMessages"`
+	MessagesPerDay        []DailyCount   `json:"messagesPerDay"`
+	ActiveMembers         int            `json:"activeMembers"`
+	ActiveMemberIDs       []string       `json:"activeMemberIds"`
+	ActionsByType         map[string]int `json:"actionsByType"`
+	Meetings              int            `json:"meetings"`
+	MeetingMinutes        int            `json:"meetingMinutes"`
+	AttendeeMinutes       int            `json:"attendeeMinutes"`
+	Joins                 int            `json:"joins"`
+	Leaves                int            `json:"leaves"`
+	NetMembers            int            `json:"netMembers"`
+	MedianResponseSeconds *float64       `json:"medianResponseSeconds"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
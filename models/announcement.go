@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Announcement is shown at the top of a group until it expires. Members
+// acknowledge it once they have read it.
+type Announcement struct {
+	ID             string            `json:"id"`
+	Content        string            `json:"content"`
+	PostedBy       string            `json:"postedBy"`
+	PostedAt       time.Time         `json:"postedAt"`
+	ExpiresAt      *time.Time        `json:"expiresAt,omitempty"`
+	AcknowledgedBy []Acknowledgement `json:"acknowledgedBy"`
+}
+
+type Acknowledgement struct {
+	UserID         string    `json:"userId"`
+	AcknowledgedAt time.Time `json:"acknowledgedAt"`
+}
+
+// PinnedMessage keeps a copy of a message at the top of its group
+type PinnedMessage struct {
+	Message  Message   `json:"message"`
+	PinnedBy string    `json:"pinnedBy"`
+	PinnedAt time.Time `json:"pinnedAt"`
+}
+
+type AnnouncementRequest struct {
+	ActorID        string `json:"actor_id" binding:"required"`
+	Content        string `json:"content" binding:"required"`
+	ExpiresInHours int    `json:"expires_in_hours"`
+}
+
+type PinRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+}
+
+// AnnouncementReceipts splits a group's members into those who have
+// acknowledged an announcement and those who have not
+type AnnouncementReceipts struct {
+	Announcement *Announcement     `json:"announcement"`
+	Acknowledged []Acknowledgement `json:"acknowledged"`
+	Pending      []string          `json:"pending"`
+}
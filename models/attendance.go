@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// AttendanceRecord is written for every member who took part in a meeting
+// once the meeting has ended
+type AttendanceRecord struct {
+	ID        string    `json:"id"`
+	GroupID   string    `json:"groupId"`
+	MeetingID string    `json:"meetingId"`
+	SessionID string    `json:"sessionId,omitempty"`
+	UserID    string    `json:"userId"`
+	JoinedAt  time.Time `json:"joinedAt"`
+	LeftAt    time.Time `json:"leftAt"`
+	Minutes   int       `json:"minutes"`
+}
+
+// StudyStats sums up a user's attendance. Days and weeks are UTC calendar
+// days and Monday-based weeks.
+type StudyStats struct {
+	CurrentStreakDays int             `json:"currentStreakDays"`
+	LongestStreakDays int             `json:"longestStreakDays"`
+	LastStudiedOn     string          `json:"lastStudiedOn,omitempty"`
+	MeetingsAttended  int             `json:"meetingsAttended"`
+	TotalMinutes      int             `json:"totalMinutes"`
+	WeeklyMinutes     []WeeklyMinutes `json:"weeklyMinutes"`
+}
+
+type WeeklyMinutes struct {
+	WeekStart string `json:"weekStart"`
+	Minutes   int    `json:"minutes"`
+}
+
+type UserProfile struct {
+	User       User       `json:"user"`
+	StudyStats StudyStats `json:"studyStats"`
+}
+
+// GroupAttendanceReport shows how often each member turned up to the
+// group's finished meetings
+type GroupAttendanceReport struct {
+	GroupID      string             `json:"groupId"`
+	Title        string             `json:"title"`
+	MeetingsHeld int                `json:"meetingsHeld"`
+	Members      []MemberAttendance `json:"members"`
+}
+
+type MemberAttendance struct {
+	UserID           string     `json:"userId"`
+	MeetingsAttended int        `json:"meetingsAttended"`
+	AttendanceRate   float64    `json:"attendanceRate"`
+	TotalMinutes     int        `json:"totalMinutes"`
+	LastAttendedAt   *time.Time `json:"lastAttendedAt,omitempty"`
+	CurrentMember    bool       `json:"currentMember"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// BreakoutRequest splits members of a group off into a breakout group with
+// its own chat and actions
+type BreakoutRequest struct {
+	ActorID string   `json:"actor_id" binding:"required"`
+	Title   string   `json:"title"`
+	Members []string `json:"members" binding:"required"`
+}
+
+// AutoBreakoutRequest splits every member not already in a breakout into
+// groups of Size, pairing the most similar members first
+type AutoBreakoutRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+	Size    int    `json:"size"`
+}
+
+type CloseBreakoutsRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+}
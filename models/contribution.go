@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// QuestionAnswer is a member's answer to one of the group's questions.
+// Only the first answer counts.
+type QuestionAnswer struct {
+	ID         string    `json:"id"`
+	GroupID    string    `json:"groupId"`
+	QuestionID string    `json:"questionId"`
+	UserID     string    `json:"userId"`
+	Option     int       `json:"option"`
+	Correct    bool      `json:"correct"`
+	AnsweredAt time.Time `json:"answeredAt"`
+}
+
+// Kudos thanks another member for their help, optionally for a message
+type Kudos struct {
+	ID         string    `json:"id"`
+	GroupID    string    `json:"groupId"`
+	FromUserID string    `json:"fromUserId"`
+	ToUserID   string    `json:"toUserId"`
+	MessageID  string    `json:"messageId,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type AnswerRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Option *int   `json:"option" binding:"required"`
+}
+
+type KudosRequest struct {
+	ActorID   string `json:"actor_id" binding:"required"`
+	MessageID string `json:"message_id"`
+	Reason    string `json:"reason"`
+}
+
+// Leaderboard windows
+const (
+	LeaderboardWeek = "week"
+	LeaderboardAll  = "all"
+)
+
+type Leaderboard struct {
+	GroupID string             `json:"groupId"`
+	Window  string             `json:"window"`
+	Since   *time.Time         `json:"since,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+type LeaderboardEntry struct {
+	Rank             int    `json:"rank"`
+	UserID           string `json:"userId"`
+	Score            int    `json:"score"`
+	MessagesSent     int    `json:"messagesSent"`
+	CorrectAnswers   int    `json:"correctAnswers"`
+	MeetingsAttended int    `json:"meetingsAttended"`
+	HelpGiven        int    `json:"helpGiven"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Event is something that happened in a group, or to one user when UserID
+// is set, streamed to connected clients as it happens. IDs increase in the
+// order events were published.
+type Event struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	GroupID   string    `json:"groupId,omitempty"`
+	UserID    string    `json:"userId,omitempty"`
+	Data      any       `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+const (
+	EventMessage        = "MESSAGE"
+	EventAction         = "ACTION"
+	EventMemberJoined   = "MEMBER_JOINED"
+	EventMemberLeft     = "MEMBER_LEFT"
+	EventMeetingStarted = "MEETING_STARTED"
+	EventMeetingUpdated = "MEETING_UPDATED"
+	EventMeetingEnded   = "MEETING_ENDED"
+
+	EventRecommendationsChanged = "RECOMMENDATIONS_CHANGED"
+)
+
+// ChatFrame is sent by clients over a group's chat socket. Ref is echoed
+// back in the acknowledgement so clients can match the two up.
+type ChatFrame struct {
+	Type    string `json:"type"`
+	Ref     string `json:"ref"`
+	Content string `json:"content"`
+}
+
+const ChatFrameMessage = "message"
+
+// ChatAck tells the client whether the frame with Ref was delivered
+type ChatAck struct {
+	Type  string `json:"type"`
+	Ref   string `json:"ref"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// UserDataExport holds everything the system stores about a single user
+type UserDataExport struct {
+	GeneratedAt     time.Time                `json:"generatedAt"`
+	Profile         User                     `json:"profile"`
+	Memberships     []ExportedMembership     `json:"memberships"`
+	Messages        []ExportedMessage        `json:"messages"`
+	Actions         []ExportedAction         `json:"actions"`
+	Matches         []ExportedMatch          `json:"matches"`
+	Recommendations []ExportedGroupReference `json:"recommendations"`
+	Rejections      []ExportedGroupReference `json:"rejections"`
+	Invites         []Invite                 `json:"invites"`
+	JoinRequests    []JoinRequest            `json:"joinRequests"`
+	RSVPs           []SessionRSVP            `json:"rsvps"`
+	Attendance      []AttendanceRecord       `json:"attendance"`
+	Answers         []QuestionAnswer         `json:"answers"`
+	Kudos           []Kudos                  `json:"kudos"`
+}
+
+type ExportedMembership struct {
+	GroupID string `json:"groupId"`
+	Title   string `json:"title"`
+	Tag     string `json:"tag"`
+	Type    string `json:"type"`
+	Owner   bool   `json:"owner"`
+}
+
+type ExportedMessage struct {
+	GroupID string  `json:"groupId"`
+	Message Message `json:"message"`
+}
+
+type ExportedAction struct {
+	GroupID string `json:"groupId"`
+	Action  Action `json:"action"`
+}
+
+// ExportedMatch only carries the other user's public details so that an
+// export never leaks someone else's scores or email
+type ExportedMatch struct {
+	UserID     string  `json:"userId"`
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"`
+}
+
+type ExportedGroupReference struct {
+	GroupID string `json:"groupId"`
+	Title   string `json:"title,omitempty"`
+}
@@ -3,44 +3,73 @@ package models
 import "time"
 
 type Group struct {
-	ID                   string     `json:"id"`
-	Title                string     `json:"title"`
-	Description          string     `json:"description"`
-	Members              []string   `json:"members"`
-	Tag                  string     `json:"tag"`
-	Type                 string     `json:"type"`
-	Private              bool       `json:"private"`
-	Messages             []Message  `json:"messages"`
-	Actions              []Action   `json:"actions"`
-	CreateBy             string     `json:"createBy"`
-	Capacity             int        `json:"capacity"`
-	ActivityScore        int        `json:"activityScore"`
-	MeetingStarted       bool       `json:"meetingStarted"`
-	Questions            []Question `json:"questions"`
-	RecommendationReason string     `json:"recommendationReason"`
-	RecommendationTag    string     `json:"recommendationScore"`
+	ID                   string          `json:"id"`
+	Title                string          `json:"title"`
+	Description          string          `json:"description"`
+	Announcements        []Announcement  `json:"announcements"`
+	PinnedMessages       []PinnedMessage `json:"pinnedMessages"`
+	Members              []string        `json:"members"`
+	Tag                  string          `json:"tag"`
+	Tags                 []string        `json:"tags"`
+	Type                 string          `json:"type"`
+	Private              bool            `json:"private"`
+	JoinPolicy           string          `json:"joinPolicy"`
+	Moderators           []string        `json:"moderators"`
+	Messages             []Message       `json:"messages"`
+	Actions              []Action        `json:"actions"`
+	CreateBy             string          `json:"createBy"`
+	CreatedAt            time.Time       `json:"createdAt"`
+	Capacity             int             `json:"capacity"`
+	ActivityScore        int             `json:"activityScore"`
+	MeetingStarted       bool            `json:"meetingStarted"`
+	Questions            []Question      `json:"questions"`
+	RecommendationReason string          `json:"recommendationReason"`
+	RecommendationTag    string          `json:"recommendationScore"`
+	EmptySince           *time.Time      `json:"emptySince,omitempty"`
+	Archived             bool            `json:"archived"`
+	ArchivedAt           *time.Time      `json:"archivedAt,omitempty"`
+	ExpiresAt            *time.Time      `json:"expiresAt,omitempty"`
+	LeaderboardOptOut    []string        `json:"leaderboardOptOut,omitempty"`
+	DormantSince         *time.Time      `json:"dormantSince,omitempty"`
+	KeptOpenAt           *time.Time      `json:"keptOpenAt,omitempty"`
+	MergedInto           string          `json:"mergedInto,omitempty"`
+	ParentID             string          `json:"parentId,omitempty"`
+	// Moderation state is only shown through the moderation endpoints
+	Banned        []string             `json:"-"`
+	TimedOutUntil map[string]time.Time `json:"-"`
 }
 
+// Question is a multiple choice question. CorrectOption is the index of the
+// right option; it is never sent to clients.
 type Question struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	Options   []string  `json:"options"`
-	Timestamp time.Time `json:"timestamp"`
+	ID            string    `json:"id"`
+	Content       string    `json:"content"`
+	Options       []string  `json:"options"`
+	Timestamp     time.Time `json:"timestamp"`
+	CorrectOption *int      `json:"-"`
 }
 
+// Messages and actions brought over by a merge keep the ID and title of the
+// group they were first posted in. ActionID is set on the message that
+// announces an action in the chat.
 type Message struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	SenderId  string    `json:"senderId"`
-	Timestamp time.Time `json:"timestamp"`
+	ID          string    `json:"id"`
+	Content     string    `json:"content"`
+	SenderId    string    `json:"senderId"`
+	Timestamp   time.Time `json:"timestamp"`
+	ActionID    string    `json:"actionId,omitempty"`
+	FromGroupID string    `json:"fromGroupId,omitempty"`
+	FromGroup   string    `json:"fromGroup,omitempty"`
 }
 
 type Action struct {
-	ID        string    `json:"id"`
-	Type      string    `json:"type"`
-	Content   string    `json:"content"`
-	SenderId  string    `json:"senderId"`
-	Timestamp time.Time `json:"timestamp"`
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Content     string    `json:"content"`
+	SenderId    string    `json:"senderId"`
+	Timestamp   time.Time `json:"timestamp"`
+	FromGroupID string    `json:"fromGroupId,omitempty"`
+	FromGroup   string    `json:"fromGroup,omitempty"`
 }
 
 const (
@@ -48,10 +77,23 @@ const (
 	ActionTypeTest = "TEST"
 )
 
+// Join policies decide whether joining a group needs the owner's approval.
+// Invite-only groups can only be joined through an invite or a recommendation.
+const (
+	JoinPolicyOpen       = "OPEN"
+	JoinPolicyApproval   = "APPROVAL"
+	JoinPolicyInviteOnly = "INVITE_ONLY"
+)
+
+// GroupUpdateRequest posts a message or action. MeetingStarted starts or ends
+// the group's meeting on behalf of UserID when it is set.
+// Timestamps sent with a message or action are ignored; the server stamps
+// posts when it receives them.
 type GroupUpdateRequest struct {
 	Message        *MessageUpdate `json:"message,omitempty"`
 	Action         *ActionUpdate  `json:"action,omitempty"`
-	MeetingStarted bool           `json:"meetingStarted"`
+	MeetingStarted *bool          `json:"meetingStarted,omitempty"`
+	UserID         string         `json:"user_id,omitempty"`
 }
 
 type MessageUpdate struct {
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// GroupChange records one field of a group's details being edited
+type GroupChange struct {
+	ID        string    `json:"id"`
+	GroupID   string    `json:"groupId"`
+	ChangedBy string    `json:"changedBy"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"oldValue"`
+	NewValue  string    `json:"newValue"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GroupPatchRequest edits group details. Fields left out are not changed.
+type GroupPatchRequest struct {
+	ActorID     string    `json:"actor_id" binding:"required"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Tag         *string   `json:"tag"`
+	Tags        *[]string `json:"tags"`
+	Type        *string   `json:"type"`
+	Capacity    *int      `json:"capacity"`
+	Private     *bool     `json:"private"`
+	JoinPolicy  *string   `json:"join_policy"`
+}
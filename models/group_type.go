@@ -0,0 +1,25 @@
+package models
+
+// GroupType holds the rules every group of that type follows
+type GroupType struct {
+	Name           string `json:"name"`
+	MinCapacity    int    `json:"minCapacity"`
+	MaxCapacity    int    `json:"maxCapacity"`
+	DefaultPrivate bool   `json:"defaultPrivate"`
+	Creators       string `json:"creators"`
+	JoinPolicy     string `json:"joinPolicy"`
+	LifespanDays   int    `json:"lifespanDays,omitempty"`
+}
+
+// Who may create groups of a type
+const (
+	CreatorsAnyone = "ANYONE"
+	CreatorsAdmin  = "ADMIN"
+	CreatorsSystem = "SYSTEM"
+)
+
+const (
+	GroupTypeStudy         = "study"
+	GroupTypeTopicWeakness = "Topic Weakness"
+	GroupTypePairStudy     = "Pair Study"
+)
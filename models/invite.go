@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Invite brings people into a group, including private ones. Code and link
+// invites can be shared with anyone, user invites only work for the invitee.
+type Invite struct {
+	ID         string     `json:"id"`
+	GroupID    string     `json:"groupId"`
+	Kind       string     `json:"kind"`
+	Code       string     `json:"code"`
+	Link       string     `json:"link,omitempty"`
+	InviteeID  string     `json:"inviteeId,omitempty"`
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	MaxUses    int        `json:"maxUses"`
+	Uses       int        `json:"uses"`
+	RedeemedBy []string   `json:"redeemedBy"`
+	Revoked    bool       `json:"revoked"`
+}
+
+const (
+	InviteKindCode = "CODE"
+	InviteKindLink = "LINK"
+	InviteKindUser = "USER"
+)
+
+type InviteCreateRequest struct {
+	UserID         string `json:"user_id" binding:"required"`
+	Kind           string `json:"kind" binding:"required"`
+	InviteeID      string `json:"invitee_id"`
+	ExpiresInHours int    `json:"expires_in_hours"`
+	MaxUses        int    `json:"max_uses"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type JoinRequest struct {
+	ID        string     `json:"id"`
+	GroupID   string     `json:"groupId"`
+	UserID    string     `json:"userId"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DecidedBy string     `json:"decidedBy,omitempty"`
+	DecidedAt *time.Time `json:"decidedAt,omitempty"`
+}
+
+const (
+	JoinRequestPending  = "PENDING"
+	JoinRequestApproved = "APPROVED"
+	JoinRequestRejected = "REJECTED"
+)
+
+// JoinResult describes what happened when a user asked to join a group
+type JoinResult struct {
+	Status   string            `json:"status"`
+	Request  *JoinRequest      `json:"request,omitempty"`
+	Waitlist *WaitlistPosition `json:"waitlist,omitempty"`
+}
+
+const (
+	JoinStatusJoined     = "JOINED"
+	JoinStatusPending    = "PENDING_APPROVAL"
+	JoinStatusWaitlisted = "WAITLISTED"
+)
+
+type JoinRequestDecision struct {
+	ActorID string `json:"actor_id" binding:"required"`
+}
+
+type ModeratorRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+}
+
+type OwnershipTransferRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+}
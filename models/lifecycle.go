@@ -0,0 +1,25 @@
+package models
+
+// EmptyGroupSettings is how many hours a group can go without members
+// before it is archived, and before it is deleted. 0 switches a step off.
+type EmptyGroupSettings struct {
+	ArchiveAfterHours float64 `json:"archiveAfterHours"`
+	DeleteAfterHours  float64 `json:"deleteAfterHours"`
+}
+
+type EmptyGroupSettingsRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+	EmptyGroupSettings
+}
+
+// DormancySettings is how many quiet hours flag a group as dormant, and how
+// many hours after that it is archived. An archive period of 0 never archives.
+type DormancySettings struct {
+	InactiveAfterHours float64 `json:"inactiveAfterHours"`
+	ArchiveAfterHours  float64 `json:"archiveAfterHours"`
+}
+
+type DormancySettingsRequest struct {
+	ActorID string `json:"actor_id" binding:"required"`
+	DormancySettings
+}
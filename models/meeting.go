@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Meeting is a live session of a group. A group has at most one open meeting
+// at a time, and Group.MeetingStarted mirrors whether it does.
+type Meeting struct {
+	ID         string              `json:"id"`
+	GroupID    string              `json:"groupId"`
+	SessionID  string              `json:"sessionId,omitempty"`
+	HostID     string              `json:"hostId"`
+	StartedAt  time.Time           `json:"startedAt"`
+	EndedAt    *time.Time          `json:"endedAt,omitempty"`
+	EndedBy    string              `json:"endedBy,omitempty"`
+	Attendance []MeetingAttendance `json:"attendance"`
+	Attendees  []MeetingAttendee   `json:"attendees"`
+}
+
+// MeetingAttendance is one stretch of time a user spent in a meeting. Users
+// who drop out and rejoin get one entry per stretch.
+type MeetingAttendance struct {
+	UserID   string     `json:"userId"`
+	JoinedAt time.Time  `json:"joinedAt"`
+	LeftAt   *time.Time `json:"leftAt,omitempty"`
+}
+
+// MeetingAttendee sums up a user's attendance. Time still running for users
+// in an open meeting is counted up to when the summary was last updated.
+type MeetingAttendee struct {
+	UserID          string    `json:"userId"`
+	FirstJoinedAt   time.Time `json:"firstJoinedAt"`
+	Present         bool      `json:"present"`
+	DurationSeconds int64     `json:"durationSeconds"`
+}
+
+type MeetingStartRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	SessionID string `json:"session_id"`
+}
+
+type MeetingRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
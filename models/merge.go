@@ -0,0 +1,30 @@
+package models
+
+// GroupMergeRequest merges the source groups into the target group
+type GroupMergeRequest struct {
+	ActorID   string   `json:"actor_id" binding:"required"`
+	TargetID  string   `json:"target_id" binding:"required"`
+	SourceIDs []string `json:"source_ids" binding:"required"`
+}
+
+// GroupMergeResult lists who got a seat in the merged group, who was put on
+// its waitlist because it ran out of room, and who was left out because they
+// are banned from it
+type GroupMergeResult struct {
+	Group      *Group   `json:"group"`
+	Merged     []string `json:"merged"`
+	Moved      []string `json:"moved"`
+	Waitlisted []string `json:"waitlisted"`
+	Banned     []string `json:"banned"`
+}
+
+// GroupMergeSuggestion proposes merging quiet, half-empty groups that share
+// a tag and type into the busiest of them
+type GroupMergeSuggestion struct {
+	TargetID  string   `json:"targetId"`
+	SourceIDs []string `json:"sourceIds"`
+	Tag       string   `json:"tag"`
+	Type      string   `json:"type"`
+	Members   int      `json:"members"`
+	Capacity  int      `json:"capacity"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// ModerationAction is one entry in the moderation audit trail. Until is set
+// for timeouts.
+type ModerationAction struct {
+	ID        string     `json:"id"`
+	GroupID   string     `json:"groupId"`
+	ActorID   string     `json:"actorId"`
+	UserID    string     `json:"userId"`
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	Until     *time.Time `json:"until,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+const (
+	ModerationKick       = "KICK"
+	ModerationBan        = "BAN"
+	ModerationUnban      = "UNBAN"
+	ModerationTimeout    = "TIMEOUT"
+	ModerationEndTimeout = "END_TIMEOUT"
+)
+
+// ModerationState lists who is banned from a group and who is timed out of
+// it right now
+type ModerationState struct {
+	GroupID       string               `json:"groupId"`
+	Banned        []string             `json:"banned"`
+	TimedOutUntil map[string]time.Time `json:"timedOutUntil"`
+}
+
+// ModerationRequest asks for a moderation action. DurationMinutes is only
+// used for timeouts.
+type ModerationRequest struct {
+	ActorID         string `json:"actor_id" binding:"required"`
+	Reason          string `json:"reason" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
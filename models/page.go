@@ -0,0 +1,33 @@
+package models
+
+// Page is one page of a cursor-paginated list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// PageRequest asks for the page after Cursor, or the first page when it is empty
+type PageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+// GroupListOptions sorts, filters and pages a list of groups. All returns
+// every matching group as a single page instead.
+type GroupListOptions struct {
+	PageRequest
+	Sort         string
+	Type         string
+	Private      *bool
+	MinFreeSeats int
+	CreatedBy    string
+	All          bool
+}
+
+const (
+	GroupSortActivity  = "activity"
+	GroupSortNewest    = "newest"
+	GroupSortMembers   = "members"
+	GroupSortFreeSeats = "free_seats"
+	GroupSortRelevance = "relevance"
+)
@@ -0,0 +1,9 @@
+package models
+
+// GroupSearchResult is a group found by full-text search with its ranking.
+// Relevance is the text match alone, Score blends it with activity.
+type GroupSearchResult struct {
+	Group     *Group  `json:"group"`
+	Relevance float64 `json:"relevance"`
+	Score     float64 `json:"score"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// StudySession is a meeting on a group's calendar. Start is kept together with
+// the organiser's Timezone so recurring sessions stay at the same local time
+// across daylight saving changes.
+type StudySession struct {
+	ID              string    `json:"id"`
+	GroupID         string    `json:"groupId"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	OrganizerID     string    `json:"organizerId"`
+	Timezone        string    `json:"timezone"`
+	Start           time.Time `json:"start"`
+	DurationMinutes int       `json:"durationMinutes"`
+	RRule           string    `json:"rrule,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// SessionRSVP is a member's answer for every occurrence of a session
+type SessionRSVP struct {
+	SessionID string    `json:"sessionId"`
+	GroupID   string    `json:"groupId"`
+	UserID    string    `json:"userId"`
+	Response  string    `json:"response"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+const (
+	RSVPGoing    = "GOING"
+	RSVPMaybe    = "MAYBE"
+	RSVPDeclined = "DECLINED"
+)
+
+type SessionOccurrence struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type SessionDetails struct {
+	Session  *StudySession       `json:"session"`
+	Upcoming []SessionOccurrence `json:"upcoming"`
+	RSVPs    []*SessionRSVP      `json:"rsvps"`
+}
+
+// SessionCreateRequest takes Start as local time in Timezone, e.g.
+// "2026-10-20T18:00" and "Asia/Kolkata"
+type SessionCreateRequest struct {
+	UserID          string `json:"user_id" binding:"required"`
+	Title           string `json:"title" binding:"required"`
+	Description     string `json:"description"`
+	Start           string `json:"start" binding:"required"`
+	Timezone        string `json:"timezone" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required"`
+	RRule           string `json:"rrule"`
+}
+
+type SessionRSVPRequest struct {
+	Response string `json:"response" binding:"required"`
+}
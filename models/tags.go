@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// TagSynonym maps an alternative spelling of a tag onto its canonical form
+type TagSynonym struct {
+	Alias     string `json:"alias"`
+	Canonical string `json:"canonical"`
+}
+
+type TagSynonymRequest struct {
+	ActorID   string `json:"actor_id" binding:"required"`
+	Alias     string `json:"alias" binding:"required"`
+	Canonical string `json:"canonical" binding:"required"`
+}
+
+var tagFolder = cases.Fold()
+
+// NormalizeTag folds case and compatibility forms, strips accents and
+// collapses whitespace so that "Physics", " physics " and "Phýsics" compare equal
+func NormalizeTag(tag string) string {
+	stripAccents := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripAccents, tag)
+	if err != nil {
+		folded = tag
+	}
+	return strings.Join(strings.Fields(tagFolder.String(folded)), " ")
+}
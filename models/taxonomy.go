@@ -0,0 +1,27 @@
+package models
+
+// TaxonomyNode is one entry in the subject → chapter → topic tree that groups
+// are tagged with. IDs are the dotted path of slugs, e.g. "physics.thermodynamics".
+type TaxonomyNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Level    string `json:"level"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+const (
+	TaxonomySubject = "SUBJECT"
+	TaxonomyChapter = "CHAPTER"
+	TaxonomyTopic   = "TOPIC"
+)
+
+type TaxonomyTree struct {
+	TaxonomyNode
+	Children []*TaxonomyTree `json:"children"`
+}
+
+type TaxonomyNodeRequest struct {
+	ActorID  string `json:"actor_id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parent_id"`
+}
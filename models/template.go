@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// GroupTemplate is a reusable group configuration. Title, description,
+// welcome message, tag and questions may contain {{placeholders}} that are
+// filled in when a group is made from the template.
+type GroupTemplate struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	Title          string             `json:"title"`
+	Description    string             `json:"description"`
+	WelcomeMessage string             `json:"welcomeMessage"`
+	Tag            string             `json:"tag"`
+	Tags           []string           `json:"tags"`
+	Type           string             `json:"type"`
+	Capacity       int                `json:"capacity"`
+	Private        *bool              `json:"private,omitempty"`
+	JoinPolicy     string             `json:"joinPolicy,omitempty"`
+	Questions      []TemplateQuestion `json:"questions"`
+	Parameters     []string           `json:"parameters"`
+	CreatedBy      string             `json:"createdBy"`
+	CreatedAt      time.Time          `json:"createdAt"`
+}
+
+type TemplateQuestion struct {
+	Content       string   `json:"content"`
+	Options       []string `json:"options"`
+	CorrectOption *int     `json:"correctOption,omitempty"`
+}
+
+type GroupTemplateRequest struct {
+	ActorID        string             `json:"actor_id" binding:"required"`
+	Name           string             `json:"name" binding:"required"`
+	Title          string             `json:"title" binding:"required"`
+	Description    string             `json:"description"`
+	WelcomeMessage string             `json:"welcome_message"`
+	Tag            string             `json:"tag"`
+	Tags           []string           `json:"tags"`
+	Type           string             `json:"type" binding:"required"`
+	Capacity       int                `json:"capacity"`
+	Private        *bool              `json:"private"`
+	JoinPolicy     string             `json:"join_policy"`
+	Questions      []TemplateQuestion `json:"questions"`
+}
+
+// TemplateInstantiateRequest makes one group owned by UserID from a template
+type TemplateInstantiateRequest struct {
+	UserID string            `json:"user_id" binding:"required"`
+	Params map[string]string `json:"params"`
+}
+
+// TemplateBulkRequest makes one group per batch, each batch giving the
+// placeholder values for its group
+type TemplateBulkRequest struct {
+	UserID  string              `json:"user_id" binding:"required"`
+	Batches []map[string]string `json:"batches" binding:"required"`
+}
+
+type BulkCreateResult struct {
+	Created []*Group          `json:"created"`
+	Failed  []BulkCreateError `json:"failed"`
+}
+
+type BulkCreateError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// CloneGroupRequest copies a group's configuration, optionally under a new title
+type CloneGroupRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Title  string `json:"title"`
+}
@@ -5,8 +5,11 @@ type User struct {
 	Email string  `json:"email"`
 	Score []Score `json:"score"`
 	Name  string  `json:"name"`
+	Role  string  `json:"role,omitempty"`
 }
 
+const RoleAdmin = "admin"
+
 type Score struct {
 	Subject string `json:"subject"`
 	Score   int    `json:"score"`
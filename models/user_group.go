@@ -5,10 +5,13 @@ type UserGroup struct {
 	UserID            string   `json:"user_id"`
 	ActiveGroups      []string `json:"active_groups"`
 	RecommendedGroups []string `json:"recommended_groups"`
+	RejectedGroups    []string `json:"rejected_groups"`
 }
 
 type GroupsPageResponse struct {
 	SystemRecommendedGroups []Group `json:"system_recommended_groups"`
 	UserActiveGroups        []Group `json:"user_active_groups"`
 	User                    User    `json:"user"`
+	ActiveNextCursor        string  `json:"active_next_cursor,omitempty"`
+	RecommendedNextCursor   string  `json:"recommended_next_cursor,omitempty"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// WaitlistEntry queues a user for a seat in a full group. When a seat frees up
+// the first waiting user is admitted and has until ConfirmBy to confirm it.
+type WaitlistEntry struct {
+	ID          string     `json:"id"`
+	GroupID     string     `json:"groupId"`
+	UserID      string     `json:"userId"`
+	Status      string     `json:"status"`
+	JoinedAt    time.Time  `json:"joinedAt"`
+	AdmittedAt  *time.Time `json:"admittedAt,omitempty"`
+	ConfirmBy   *time.Time `json:"confirmBy,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmedAt,omitempty"`
+}
+
+const (
+	WaitlistWaiting   = "WAITING"
+	WaitlistAdmitted  = "ADMITTED"
+	WaitlistConfirmed = "CONFIRMED"
+	WaitlistExpired   = "EXPIRED"
+	WaitlistLeft      = "LEFT"
+)
+
+type WaitlistPosition struct {
+	Entry    *WaitlistEntry `json:"entry"`
+	Position int            `json:"position,omitempty"`
+	Waiting  int            `json:"waiting"`
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultActivityWeights make a meeting worth ten messages and let activity
+// fade to half its weight over a week
+var DefaultActivityWeights = models.ActivityWeights{
+	Message:       1,
+	Action:        2,
+	Meeting:       10,
+	Join:          5,
+	ActiveMember:  8,
+	HalfLifeHours: 7 * 24,
+}
+
+func (s *GroupService) GetActivityWeights() models.ActivityWeights {
+	return s.activityWeights
+}
+
+// SetActivityWeights changes the scoring weights (admin only) and rescores
+// every group with them
+func (s *GroupService) SetActivityWeights(actorID string, weights models.ActivityWeights) error {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return err
+	}
+	if weights.Message < 0 || weights.Action < 0 || weights.Meeting < 0 || weights.Join < 0 || weights.ActiveMember < 0 {
+		return fmt.Errorf("activity weights cannot be negative")
+	}
+	if weights.HalfLifeHours <= 0 {
+		return fmt.Errorf("half-life must be a positive number of hours")
+	}
+	s.activityWeights = weights
+	return s.RecomputeActivityScores()
+}
+
+// RecomputeActivityScores rescores every group. Scores decay with time even
+// without new activity, so this runs on a schedule.
+func (s *GroupService) RecomputeActivityScores() error {
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, group := range groups {
+		if err := s.updateActivityScore(group, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshActivityScore rescores one group straight after something happened
+// in it, and wakes it if it had been flagged as dormant
+func (s *GroupService) refreshActivityScore(groupID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil || group == nil {
+		return err
+	}
+	if group.DormantSince != nil {
+		lastActive, err := s.lastActivity(group)
+		if err != nil {
+			return err
+		}
+		if lastActive.After(*group.DormantSince) {
+			if err := s.wakeGroup(group); err != nil {
+				return err
+			}
+		}
+	}
+	return s.updateActivityScore(group, time.Now())
+}
+
+func (s *GroupService) updateActivityScore(group *models.Group, now time.Time) error {
+	meetings, err := s.store.GetMeetingsByGroup(group.ID)
+	if err != nil {
+		return err
+	}
+	memberships, err := s.store.GetMembershipEvents(group.ID)
+	if err != nil {
+		return err
+	}
+
+	score := activityScore(group, meetings, memberships, s.activityWeights, now)
+	if score == group.ActivityScore {
+		return nil
+	}
+	group.ActivityScore = score
+	return s.store.UpdateGroup(group)
+}
+
+// activityScore adds up the group's messages, actions, meetings and joins,
+// each weighted by how recent it is, plus a share for every member by how
+// recently they last took part
+func activityScore(group *models.Group, meetings []*models.Meeting, memberships []*models.MembershipEvent, weights models.ActivityWeights, now time.Time) int {
+	halfLife := time.Duration(weights.HalfLifeHours * float64(time.Hour))
+	decay := func(at time.Time) float64 {
+		age := now.Sub(at)
+		if age < 0 || halfLife <= 0 {
+			return 1
+		}
+		return math.Exp2(-float64(age) / float64(halfLife))
+	}
+
+	score := 0.0
+	lastActive := make(map[string]time.Time)
+	seen := func(userID string, at time.Time) {
+		if userID == "" || userID == "system" {
+			return
+		}
+		if at.After(lastActive[userID]) {
+			lastActive[userID] = at
+		}
+	}
+
+	for _, message := range group.Messages {
+		// System notices are not activity by the members, and actions count below
+		if !isMemberMessage(message) {
+			continue
+		}
+		score += weights.Message * decay(message.Timestamp)
+		seen(message.SenderId, message.Timestamp)
+	}
+	for _, action := range group.Actions {
+		score += weights.Action * decay(action.Timestamp)
+		seen(action.SenderId, action.Timestamp)
+	}
+	for _, meeting := range meetings {
+		score += weights.Meeting * decay(meeting.StartedAt)
+		for _, attendance := range meeting.Attendance {
+			seen(attendance.UserID, attendance.JoinedAt)
+		}
+	}
+	for _, event := range memberships {
+		if event.Type == models.MembershipJoined && event.UserID != group.CreateBy {
+			score += weights.Join * decay(event.Timestamp)
+		}
+	}
+
+	// Only people still in the group count as active members
+	for _, memberID := range group.Members {
+		if at, ok := lastActive[memberID]; ok {
+			score += weights.ActiveMember * decay(at)
+		}
+	}
+	return int(math.Round(score))
+}
+
+// recordMembership logs a join or leave and rescores the group
+func (s *GroupService) recordMembership(groupID string, userID string, eventType string) error {
+	event := &models.MembershipEvent{
+		GroupID:   groupID,
+		UserID:    userID,
+		Type:      eventType,
+		Timestamp: time.Now(),
+	}
+	if err := s.store.AddMembershipEvent(event); err != nil {
+		return err
+	}
+	if eventType == models.MembershipJoined {
+		s.publish(groupID, models.EventMemberJoined, *event)
+	} else {
+		s.publish(groupID, models.EventMemberLeft, *event)
+	}
+	return s.refreshActivityScore(groupID)
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"fmt"
+)
+
+// requireAdmin returns an error unless the user exists and is an admin
+func requireAdmin(store storage.Store, userID string) error {
+	user, err := store.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Role != models.RoleAdmin {
+		return fmt.Errorf("only admins can do this")
+	}
+	return nil
+}
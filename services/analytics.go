@@ -0,0 +1,179 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultAnalyticsRange = 30 * 24 * time.Hour
+	MaxAnalyticsRange     = 366 * 24 * time.Hour
+)
+
+// GetGroupAnalytics works out how a group did between from and to from its
+// stored messages, actions, meetings and membership events. Zero times
+// default to the last 30 days. Only the owner and moderators can see it.
+func (s *GroupService) GetGroupAnalytics(groupID string, actorID string, from time.Time, to time.Time) (*models.GroupAnalytics, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) && requireAdmin(s.store, actorID) != nil {
+		return nil, fmt.Errorf("only the group owner, a moderator or an admin can view analytics")
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-DefaultAnalyticsRange)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	if to.Sub(from) > MaxAnalyticsRange {
+		return nil, fmt.Errorf("the time range cannot be longer than %d days", int(MaxAnalyticsRange.Hours()/24))
+	}
+	inRange := func(at time.Time) bool {
+		return !at.Before(from) && at.Before(to)
+	}
+
+	analytics := &models.GroupAnalytics{
+		GroupID:         groupID,
+		From:            from,
+		To:              to,
+		MessagesPerDay:  []models.DailyCount{},
+		ActiveMemberIDs: []string{},
+		ActionsByType:   map[string]int{},
+	}
+	active := make(map[string]bool)
+	markActive := func(userID string) {
+		if userID != "" && userID != "system" {
+			active[userID] = true
+		}
+	}
+
+	// Messages per day, counting every day in the range including quiet ones
+	perDay := make(map[string]int)
+	var memberMessages []models.Message
+	for _, message := range group.Messages {
+		if !inRange(message.Timestamp) || !isMemberMessage(message) {
+			continue
+		}
+		analytics.TotalMessages++
+		perDay[message.Timestamp.UTC().Format(dayFormat)]++
+		memberMessages = append(memberMessages, message)
+		markActive(message.SenderId)
+	}
+	lastDay := to.Add(-time.Nanosecond).UTC().Truncate(24 * time.Hour)
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dayFormat)
+		analytics.MessagesPerDay = append(analytics.MessagesPerDay, models.DailyCount{Date: date, Count: perDay[date]})
+	}
+	analytics.MedianResponseSeconds = medianResponseSeconds(memberMessages)
+
+	for _, action := range group.Actions {
+		if inRange(action.Timestamp) {
+			analytics.ActionsByType[action.Type]++
+			markActive(action.SenderId)
+		}
+	}
+
+	// Meeting time is clipped to the range so long meetings at its edges only count in part
+	meetings, err := s.store.GetMeetingsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var meetingTime, attendeeTime time.Duration
+	for _, meeting := range meetings {
+		endedAt := now
+		if meeting.EndedAt != nil {
+			endedAt = *meeting.EndedAt
+		}
+		overlap := clippedDuration(meeting.StartedAt, endedAt, from, to)
+		if overlap <= 0 && !inRange(meeting.StartedAt) {
+			continue
+		}
+		analytics.Meetings++
+		meetingTime += overlap
+		for _, attendance := range meeting.Attendance {
+			leftAt := endedAt
+			if attendance.LeftAt != nil {
+				leftAt = *attendance.LeftAt
+			}
+			if duration := clippedDuration(attendance.JoinedAt, leftAt, from, to); duration > 0 {
+				attendeeTime += duration
+				markActive(attendance.UserID)
+			}
+		}
+	}
+	analytics.MeetingMinutes = int(meetingTime.Round(time.Minute).Minutes())
+	analytics.AttendeeMinutes = int(attendeeTime.Round(time.Minute).Minutes())
+
+	events, err := s.store.GetMembershipEvents(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if !inRange(event.Timestamp) {
+			continue
+		}
+		switch event.Type {
+		case models.MembershipJoined:
+			analytics.Joins++
+		case models.MembershipLeft:
+			analytics.Leaves++
+		}
+	}
+	analytics.NetMembers = analytics.Joins - analytics.Leaves
+
+	for userID := range active {
+		analytics.ActiveMemberIDs = append(analytics.ActiveMemberIDs, userID)
+	}
+	sort.Strings(analytics.ActiveMemberIDs)
+	analytics.ActiveMembers = len(analytics.ActiveMemberIDs)
+	return analytics, nil
+}
+
+// medianResponseSeconds is the median gap between a message and the next one
+// from a different member, or nil when nobody replied to anyone
+func medianResponseSeconds(messages []models.Message) *float64 {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+	var gaps []float64
+	for i := 1; i < len(messages); i++ {
+		if messages[i].SenderId != messages[i-1].SenderId {
+			gaps = append(gaps, messages[i].Timestamp.Sub(messages[i-1].Timestamp).Seconds())
+		}
+	}
+	if len(gaps) == 0 {
+		return nil
+	}
+	sort.Float64s(gaps)
+	median := gaps[len(gaps)/2]
+	if len(gaps)%2 == 0 {
+		median = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+	}
+	return &median
+}
+
+// clippedDuration is how much of [start, end) falls within [from, to)
+func clippedDuration(start time.Time, end time.Time, from time.Time, to time.Time) time.Duration {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
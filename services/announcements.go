@@ -0,0 +1,244 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxPinnedMessages     = 10
+	maxAnnouncementLength = 1000
+)
+
+// PinMessage copies one of the group's messages to the top of the group
+func (s *GroupService) PinMessage(groupID string, messageID string, actorID string) (*models.Group, error) {
+	group, err := s.manageableGroup(groupID, actorID, "pin messages")
+	if err != nil {
+		return nil, err
+	}
+	for _, pinned := range group.PinnedMessages {
+		if pinned.Message.ID == messageID {
+			return nil, fmt.Errorf("message is already pinned")
+		}
+	}
+	if len(group.PinnedMessages) >= maxPinnedMessages {
+		return nil, fmt.Errorf("a group can have at most %d pinned messages", maxPinnedMessages)
+	}
+
+	for _, message := range group.Messages {
+		if message.ID != messageID {
+			continue
+		}
+		group.PinnedMessages = append(group.PinnedMessages, models.PinnedMessage{
+			Message:  message,
+			PinnedBy: actorID,
+			PinnedAt: time.Now(),
+		})
+		return group, s.store.UpdateGroup(group)
+	}
+	return nil, fmt.Errorf("message not found")
+}
+
+func (s *GroupService) UnpinMessage(groupID string, messageID string, actorID string) (*models.Group, error) {
+	group, err := s.manageableGroup(groupID, actorID, "unpin messages")
+	if err != nil {
+		return nil, err
+	}
+	for i, pinned := range group.PinnedMessages {
+		if pinned.Message.ID == messageID {
+			group.PinnedMessages = append(group.PinnedMessages[:i], group.PinnedMessages[i+1:]...)
+			return group, s.store.UpdateGroup(group)
+		}
+	}
+	return nil, fmt.Errorf("message is not pinned")
+}
+
+// PostAnnouncement puts an announcement at the top of the group, newest
+// first, until it expires or is taken down
+func (s *GroupService) PostAnnouncement(groupID string, request *models.AnnouncementRequest) (*models.Announcement, error) {
+	group, err := s.manageableGroup(groupID, request.ActorID, "post announcements")
+	if err != nil {
+		return nil, err
+	}
+	if group.Archived {
+		return nil, fmt.Errorf("group is archived")
+	}
+	content := strings.TrimSpace(request.Content)
+	if content == "" {
+		return nil, fmt.Errorf("announcement cannot be empty")
+	}
+	if len(content) > maxAnnouncementLength {
+		return nil, fmt.Errorf("announcement cannot be longer than %d characters", maxAnnouncementLength)
+	}
+	if request.ExpiresInHours < 0 {
+		return nil, fmt.Errorf("expires_in_hours cannot be negative")
+	}
+
+	now := time.Now()
+	announcement := models.Announcement{
+		ID:             uuid.New().String(),
+		Content:        content,
+		PostedBy:       request.ActorID,
+		PostedAt:       now,
+		AcknowledgedBy: []models.Acknowledgement{},
+	}
+	if request.ExpiresInHours > 0 {
+		expiresAt := now.Add(time.Duration(request.ExpiresInHours) * time.Hour)
+		announcement.ExpiresAt = &expiresAt
+	}
+
+	// Take down anything expired while the group is being saved anyway
+	pruneAnnouncements(group, now)
+	group.Announcements = append([]models.Announcement{announcement}, group.Announcements...)
+	if err := s.store.UpdateGroup(group); err != nil {
+		return nil, err
+	}
+	return &group.Announcements[0], nil
+}
+
+func (s *GroupService) DeleteAnnouncement(groupID string, announcementID string, actorID string) error {
+	group, err := s.manageableGroup(groupID, actorID, "take down announcements")
+	if err != nil {
+		return err
+	}
+	for i, announcement := range group.Announcements {
+		if announcement.ID == announcementID {
+			group.Announcements = append(group.Announcements[:i], group.Announcements[i+1:]...)
+			return s.store.UpdateGroup(group)
+		}
+	}
+	return fmt.Errorf("announcement not found")
+}
+
+// AcknowledgeAnnouncement records that a member has seen an announcement.
+// Acknowledging twice keeps the first time.
+func (s *GroupService) AcknowledgeAnnouncement(groupID string, announcementID string, userID string) (*models.Announcement, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can acknowledge announcements")
+	}
+	announcement := findAnnouncement(group, announcementID)
+	if announcement == nil {
+		return nil, fmt.Errorf("announcement not found")
+	}
+
+	for _, acknowledgement := range announcement.AcknowledgedBy {
+		if acknowledgement.UserID == userID {
+			return announcement, nil
+		}
+	}
+	announcement.AcknowledgedBy = append(announcement.AcknowledgedBy, models.Acknowledgement{
+		UserID:         userID,
+		AcknowledgedAt: time.Now(),
+	})
+	return announcement, s.store.UpdateGroup(group)
+}
+
+// GetAnnouncementReceipts shows the owner and moderators which members have
+// acknowledged an announcement and which have not
+func (s *GroupService) GetAnnouncementReceipts(groupID string, announcementID string, actorID string) (*models.AnnouncementReceipts, error) {
+	group, err := s.manageableGroup(groupID, actorID, "see who acknowledged announcements")
+	if err != nil {
+		return nil, err
+	}
+	announcement := findAnnouncement(group, announcementID)
+	if announcement == nil {
+		return nil, fmt.Errorf("announcement not found")
+	}
+
+	receipts := &models.AnnouncementReceipts{
+		Announcement: announcement,
+		Acknowledged: []models.Acknowledgement{},
+		Pending:      []string{},
+	}
+	acknowledged := map[string]bool{}
+	for _, acknowledgement := range announcement.AcknowledgedBy {
+		acknowledged[acknowledgement.UserID] = true
+		// Members who have left since are no longer counted
+		if isMember(group, acknowledgement.UserID) {
+			receipts.Acknowledged = append(receipts.Acknowledged, acknowledgement)
+		}
+	}
+	for _, memberID := range group.Members {
+		if !acknowledged[memberID] {
+			receipts.Pending = append(receipts.Pending, memberID)
+		}
+	}
+	return receipts, nil
+}
+
+// ExpireAnnouncements takes expired announcements down from every group
+func (s *GroupService) ExpireAnnouncements() error {
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, group := range groups {
+		if !pruneAnnouncements(group, now) {
+			continue
+		}
+		if err := s.store.UpdateGroup(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentAnnouncements returns the announcements that have not expired yet
+func currentAnnouncements(group *models.Group, now time.Time) []models.Announcement {
+	current := []models.Announcement{}
+	for _, announcement := range group.Announcements {
+		if announcement.ExpiresAt == nil || now.Before(*announcement.ExpiresAt) {
+			current = append(current, announcement)
+		}
+	}
+	return current
+}
+
+// pruneAnnouncements drops expired announcements and reports whether any were dropped
+func pruneAnnouncements(group *models.Group, now time.Time) bool {
+	current := currentAnnouncements(group, now)
+	if len(current) == len(group.Announcements) {
+		return false
+	}
+	group.Announcements = current
+	return true
+}
+
+// findAnnouncement finds an announcement that has not expired yet
+func findAnnouncement(group *models.Group, announcementID string) *models.Announcement {
+	now := time.Now()
+	for i := range group.Announcements {
+		announcement := group.Announcements[i]
+		if announcement.ID == announcementID && (announcement.ExpiresAt == nil || now.Before(*announcement.ExpiresAt)) {
+			return &group.Announcements[i]
+		}
+	}
+	return nil
+}
+
+// manageableGroup loads a group the actor can manage
+func (s *GroupService) manageableGroup(groupID string, actorID string, action string) (*models.Group, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can %s", action)
+	}
+	return group, nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultStatsWeeks = 8
+	MaxStatsWeeks     = 52
+	dayFormat         = "2006-01-02"
+)
+
+// recordAttendance writes an attendance record for everyone who was in a
+// meeting that has just ended
+func (s *GroupService) recordAttendance(meeting *models.Meeting) error {
+	for _, attendee := range meeting.Attendees {
+		var leftAt time.Time
+		for _, attendance := range meeting.Attendance {
+			if attendance.UserID == attendee.UserID && attendance.LeftAt != nil && attendance.LeftAt.After(leftAt) {
+				leftAt = *attendance.LeftAt
+			}
+		}
+		record := &models.AttendanceRecord{
+			ID:        uuid.New().String(),
+			GroupID:   meeting.GroupID,
+			MeetingID: meeting.ID,
+			SessionID: meeting.SessionID,
+			UserID:    attendee.UserID,
+			JoinedAt:  attendee.FirstJoinedAt,
+			LeftAt:    leftAt,
+			Minutes:   int((attendee.DurationSeconds + 30) / 60),
+		}
+		if err := s.store.CreateAttendanceRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAttendanceReport shows the owner or a moderator how often each member,
+// past or present, came to the group's meetings
+func (s *GroupService) GetAttendanceReport(groupID string, actorID string) (*models.GroupAttendanceReport, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can view attendance")
+	}
+
+	meetings, err := s.store.GetMeetingsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	held := 0
+	for _, meeting := range meetings {
+		if meeting.EndedAt != nil {
+			held++
+		}
+	}
+
+	records, err := s.store.GetAttendanceByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[string]*models.MemberAttendance)
+	for _, memberID := range group.Members {
+		byUser[memberID] = &models.MemberAttendance{UserID: memberID, CurrentMember: true}
+	}
+	for _, record := range records {
+		member, exists := byUser[record.UserID]
+		if !exists {
+			member = &models.MemberAttendance{UserID: record.UserID}
+			byUser[record.UserID] = member
+		}
+		member.MeetingsAttended++
+		member.TotalMinutes += record.Minutes
+		if member.LastAttendedAt == nil || record.JoinedAt.After(*member.LastAttendedAt) {
+			joinedAt := record.JoinedAt
+			member.LastAttendedAt = &joinedAt
+		}
+	}
+
+	report := &models.GroupAttendanceReport{
+		GroupID:      groupID,
+		Title:        group.Title,
+		MeetingsHeld: held,
+		Members:      make([]models.MemberAttendance, 0, len(byUser)),
+	}
+	for _, member := range byUser {
+		if held > 0 {
+			member.AttendanceRate = float64(member.MeetingsAttended) / float64(held)
+		}
+		report.Members = append(report.Members, *member)
+	}
+	// Best attenders first
+	sort.Slice(report.Members, func(i, j int) bool {
+		if report.Members[i].MeetingsAttended != report.Members[j].MeetingsAttended {
+			return report.Members[i].MeetingsAttended > report.Members[j].MeetingsAttended
+		}
+		return report.Members[i].UserID < report.Members[j].UserID
+	})
+	return report, nil
+}
+
+// AttendanceReportCSV writes one row per member of an attendance report
+func AttendanceReportCSV(report *models.GroupAttendanceReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{{"user_id", "current_member", "meetings_attended", "meetings_held", "attendance_rate", "total_minutes", "last_attended_at"}}
+	for _, member := range report.Members {
+		lastAttendedAt := ""
+		if member.LastAttendedAt != nil {
+			lastAttendedAt = member.LastAttendedAt.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			member.UserID,
+			strconv.FormatBool(member.CurrentMember),
+			strconv.Itoa(member.MeetingsAttended),
+			strconv.Itoa(report.MeetingsHeld),
+			strconv.FormatFloat(member.AttendanceRate, 'f', 2, 64),
+			strconv.Itoa(member.TotalMinutes),
+			lastAttendedAt,
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// computeStudyStats works out streaks and weekly minutes from a user's
+// attendance records. A day counts towards a streak when the user attended
+// any meeting that day, and a streak is still current if it ran until
+// yesterday.
+func computeStudyStats(records []*models.AttendanceRecord, now time.Time, weeks int) models.StudyStats {
+	stats := models.StudyStats{
+		MeetingsAttended: len(records),
+		WeeklyMinutes:    make([]models.WeeklyMinutes, 0, weeks),
+	}
+
+	studied := make(map[string]bool)
+	for _, record := range records {
+		studied[record.JoinedAt.UTC().Format(dayFormat)] = true
+		stats.TotalMinutes += record.Minutes
+	}
+	days := make([]string, 0, len(studied))
+	for day := range studied {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	run := 0
+	var previous time.Time
+	for _, day := range days {
+		date, _ := time.Parse(dayFormat, day)
+		if run > 0 && date.Sub(previous) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > stats.LongestStreakDays {
+			stats.LongestStreakDays = run
+		}
+		previous = date
+	}
+	if len(days) > 0 {
+		stats.LastStudiedOn = days[len(days)-1]
+		today := now.UTC().Format(dayFormat)
+		yesterday := now.UTC().AddDate(0, 0, -1).Format(dayFormat)
+		if stats.LastStudiedOn == today || stats.LastStudiedOn == yesterday {
+			stats.CurrentStreakDays = run
+		}
+	}
+
+	// Oldest week first, ending with the current one
+	today := now.UTC().Truncate(24 * time.Hour)
+	monday := today.AddDate(0, 0, -mondayIndex(today.Weekday()))
+	for i := weeks - 1; i >= 0; i-- {
+		weekStart := monday.AddDate(0, 0, -7*i)
+		weekEnd := weekStart.AddDate(0, 0, 7)
+		week := models.WeeklyMinutes{WeekStart: weekStart.Format(dayFormat)}
+		for _, record := range records {
+			if !record.JoinedAt.Before(weekStart) && record.JoinedAt.Before(weekEnd) {
+				week.Minutes += record.Minutes
+			}
+		}
+		stats.WeeklyMinutes = append(stats.WeeklyMinutes, week)
+	}
+	return stats
+}
+
+// GetUserProfile returns the user with their study streaks and weekly study
+// minutes over the last weeks. It returns nil if the user does not exist.
+func (s *UserService) GetUserProfile(userID string, weeks int) (*models.UserProfile, error) {
+	user, err := s.store.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, nil
+	}
+	if weeks == 0 {
+		weeks = DefaultStatsWeeks
+	}
+	if weeks < 1 || weeks > MaxStatsWeeks {
+		return nil, fmt.Errorf("weeks must be between 1 and %d", MaxStatsWeeks)
+	}
+
+	records, err := s.store.GetAttendanceByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserProfile{
+		User:       *user,
+		StudyStats: computeStudyStats(records, time.Now(), weeks),
+	}, nil
+}
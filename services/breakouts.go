@@ -0,0 +1,350 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultBreakoutSize = 2
+
+// CreateBreakout splits some members of a group off into a breakout group
+// with its own chat, actions and meetings. Members can only be in one open
+// breakout of a group at a time.
+func (s *GroupService) CreateBreakout(parentID string, request *models.BreakoutRequest) (*models.Group, error) {
+	parent, err := s.breakoutParent(parentID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := uniqueSorted(request.Members)
+	if len(members) < 2 {
+		return nil, fmt.Errorf("a breakout needs at least 2 members")
+	}
+	busy, err := s.breakoutMembers(parentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberID := range members {
+		if !isMember(parent, memberID) {
+			return nil, fmt.Errorf("user %s is not a member of this group", memberID)
+		}
+		if busy[memberID] {
+			return nil, fmt.Errorf("user %s is already in a breakout", memberID)
+		}
+	}
+
+	return s.createBreakout(parent, request.Title, members)
+}
+
+// AutoBreakouts splits every member who is not in a breakout yet into groups
+// of the given size, seeding each group with the most similar pair left and
+// filling it with whoever fits best. Members left over join the group they
+// are most similar to, so nobody is left on their own.
+func (s *GroupService) AutoBreakouts(parentID string, request *models.AutoBreakoutRequest) ([]*models.Group, error) {
+	parent, err := s.breakoutParent(parentID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	size := request.Size
+	if size == 0 {
+		size = defaultBreakoutSize
+	}
+	if size < 2 {
+		return nil, fmt.Errorf("breakouts need at least 2 members")
+	}
+
+	busy, err := s.breakoutMembers(parentID)
+	if err != nil {
+		return nil, err
+	}
+	users := []models.User{}
+	for _, memberID := range uniqueSorted(parent.Members) {
+		if busy[memberID] {
+			continue
+		}
+		user, err := s.store.GetUser(memberID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			user = &models.User{ID: memberID}
+		}
+		users = append(users, *user)
+	}
+	if len(users) < size {
+		return nil, fmt.Errorf("only %d members are not in a breakout, %d are needed", len(users), size)
+	}
+
+	pairs := models.FindMatches(users, 0)
+	similarity := map[[2]string]float64{}
+	for _, pair := range pairs {
+		similarity[[2]string{pair.User1.ID, pair.User2.ID}] = pair.Similarity
+		similarity[[2]string{pair.User2.ID, pair.User1.ID}] = pair.Similarity
+	}
+	fit := func(userID string, group []string) float64 {
+		total := 0.0
+		for _, memberID := range group {
+			total += similarity[[2]string{userID, memberID}]
+		}
+		return total / float64(len(group))
+	}
+
+	assigned := map[string]bool{}
+	var groups [][]string
+	for len(users)-len(assigned) >= size {
+		var group []string
+		for _, pair := range pairs {
+			if !assigned[pair.User1.ID] && !assigned[pair.User2.ID] {
+				group = []string{pair.User1.ID, pair.User2.ID}
+				break
+			}
+		}
+		// Users without comparable scores are paired in order
+		if group == nil {
+			for _, user := range users {
+				if !assigned[user.ID] && len(group) < 2 {
+					group = append(group, user.ID)
+				}
+			}
+		}
+		assigned[group[0]], assigned[group[1]] = true, true
+		for len(group) < size {
+			best, bestFit := "", -1.0
+			for _, user := range users {
+				if assigned[user.ID] {
+					continue
+				}
+				if f := fit(user.ID, group); f > bestFit {
+					best, bestFit = user.ID, f
+				}
+			}
+			assigned[best] = true
+			group = append(group, best)
+		}
+		groups = append(groups, group)
+	}
+	for _, user := range users {
+		if assigned[user.ID] {
+			continue
+		}
+		best, bestFit := 0, -1.0
+		for i, group := range groups {
+			if f := fit(user.ID, group); f > bestFit {
+				best, bestFit = i, f
+			}
+		}
+		groups[best] = append(groups[best], user.ID)
+	}
+
+	breakouts := make([]*models.Group, 0, len(groups))
+	for _, members := range groups {
+		sort.Strings(members)
+		breakout, err := s.createBreakout(parent, "", members)
+		if err != nil {
+			return nil, err
+		}
+		breakouts = append(breakouts, breakout)
+	}
+	return breakouts, nil
+}
+
+// CloseBreakouts archives every open breakout of a group, ending any meeting
+// still running in them, and brings everyone back to the main group
+func (s *GroupService) CloseBreakouts(parentID string, actorID string) ([]*models.Group, error) {
+	parent, err := s.store.GetGroup(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(parent, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can close breakouts")
+	}
+
+	breakouts, err := s.store.GetGroupsByParent(parentID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	closed := []*models.Group{}
+	for _, breakout := range breakouts {
+		if breakout.Archived {
+			continue
+		}
+		if err := s.archiveGroup(breakout, actorID, now, "The breakout was closed"); err != nil {
+			return nil, err
+		}
+		for _, memberID := range breakout.Members {
+			if err := s.removeActiveGroup(memberID, breakout.ID); err != nil {
+				return nil, err
+			}
+		}
+		closed = append(closed, breakout)
+	}
+	if len(closed) == 0 {
+		return nil, fmt.Errorf("there are no open breakouts")
+	}
+
+	if err := s.postSystemMessage(parentID, fmt.Sprintf("%d breakouts closed, welcome back", len(closed))); err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+// GetBreakouts lists a group's breakouts, open and closed, to its members
+func (s *GroupService) GetBreakouts(parentID string, userID string) ([]*models.Group, error) {
+	parent, err := s.store.GetGroup(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(parent, userID) && !canManageGroup(parent, userID) {
+		return nil, fmt.Errorf("only group members can see its breakouts")
+	}
+	return s.store.GetGroupsByParent(parentID)
+}
+
+// breakoutParent loads a group that the actor may split into breakouts
+func (s *GroupService) breakoutParent(parentID string, actorID string) (*models.Group, error) {
+	parent, err := s.store.GetGroup(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if parent.Archived {
+		return nil, fmt.Errorf("group is archived")
+	}
+	if parent.ParentID != "" {
+		return nil, fmt.Errorf("breakouts cannot have breakouts of their own")
+	}
+	if !canManageGroup(parent, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can create breakouts")
+	}
+	return parent, nil
+}
+
+// createBreakout stores a private breakout run by the parent's owner and
+// moderators, with exactly enough room for its members
+func (s *GroupService) createBreakout(parent *models.Group, title string, members []string) (*models.Group, error) {
+	breakouts, err := s.store.GetGroupsByParent(parent.ID)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = fmt.Sprintf("%s breakout %d", parent.Title, len(breakouts)+1)
+	}
+
+	now := time.Now()
+	breakout := &models.Group{
+		ID:          uuid.New().String(),
+		Title:       title,
+		Description: fmt.Sprintf("Breakout of %s", parent.Title),
+		Members:     members,
+		Tag:         parent.Tag,
+		Tags:        append([]string{}, parent.Tags...),
+		Type:        parent.Type,
+		Private:     true,
+		JoinPolicy:  models.JoinPolicyInviteOnly,
+		Moderators:  append([]string{}, parent.Moderators...),
+		Messages: []models.Message{{
+			ID:        uuid.New().String(),
+			Content:   fmt.Sprintf("Breakout started from %s", parent.Title),
+			SenderId:  "system",
+			Timestamp: now,
+		}},
+		CreateBy:  parent.CreateBy,
+		CreatedAt: now,
+		Capacity:  len(members),
+		ParentID:  parent.ID,
+	}
+	if err := s.store.CreateGroup(breakout); err != nil {
+		return nil, err
+	}
+	for _, memberID := range members {
+		if err := s.recordMembership(breakout.ID, memberID, models.MembershipJoined); err != nil {
+			return nil, err
+		}
+		if err := s.addActiveGroup(memberID, breakout.ID); err != nil {
+			return nil, err
+		}
+	}
+	return breakout, nil
+}
+
+// addActiveGroup lists a breakout among a member's groups for as long as it
+// is open, the way joining lists any other group
+func (s *GroupService) addActiveGroup(userID string, groupID string) error {
+	userGroup, err := s.store.GetUserGroup(userID)
+	if err != nil {
+		return err
+	}
+	if userGroup == nil {
+		return s.store.CreateUserGroup(&models.UserGroup{
+			ID:                uuid.New().String(),
+			UserID:            userID,
+			ActiveGroups:      []string{groupID},
+			RecommendedGroups: []string{},
+		})
+	}
+	for _, activeGroupID := range userGroup.ActiveGroups {
+		if activeGroupID == groupID {
+			return nil
+		}
+	}
+	userGroup.ActiveGroups = append(userGroup.ActiveGroups, groupID)
+	return s.store.UpdateUserGroup(userGroup)
+}
+
+// removeActiveGroup takes a closed breakout off a member's groups
+func (s *GroupService) removeActiveGroup(userID string, groupID string) error {
+	userGroup, err := s.store.GetUserGroup(userID)
+	if err != nil || userGroup == nil {
+		return err
+	}
+	userGroup.ActiveGroups = removeID(userGroup.ActiveGroups, groupID)
+	return s.store.UpdateUserGroup(userGroup)
+}
+
+// breakoutMembers returns everyone in an open breakout of the group
+func (s *GroupService) breakoutMembers(parentID string) (map[string]bool, error) {
+	breakouts, err := s.store.GetGroupsByParent(parentID)
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]bool{}
+	for _, breakout := range breakouts {
+		if breakout.Archived {
+			continue
+		}
+		for _, memberID := range breakout.Members {
+			members[memberID] = true
+		}
+	}
+	return members, nil
+}
+
+// leaveBreakouts takes a user who leaves a group out of its open breakouts
+func (s *GroupService) leaveBreakouts(parentID string, userID string) error {
+	breakouts, err := s.store.GetGroupsByParent(parentID)
+	if err != nil {
+		return err
+	}
+	for _, breakout := range breakouts {
+		if breakout.Archived || !isMember(breakout, userID) {
+			continue
+		}
+		if err := s.LeaveGroup(breakout.ID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
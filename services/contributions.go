@@ -0,0 +1,257 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Points each kind of contribution is worth on the leaderboard
+const (
+	contributionMessage       = 1
+	contributionCorrectAnswer = 3
+	contributionMeeting       = 5
+	contributionHelp          = 4
+)
+
+const maxKudosReasonLength = 200
+
+// AnswerQuestion records a member's answer to one of the group's questions
+// and says whether it was right. Each member answers each question once.
+func (s *GroupService) AnswerQuestion(groupID string, questionID string, userID string, option int) (*models.QuestionAnswer, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can answer questions")
+	}
+	if err := checkCanPost(group, userID); err != nil {
+		return nil, err
+	}
+
+	var question *models.Question
+	for i := range group.Questions {
+		if group.Questions[i].ID == questionID {
+			question = &group.Questions[i]
+			break
+		}
+	}
+	if question == nil {
+		return nil, fmt.Errorf("question not found")
+	}
+	if option < 0 || option >= len(question.Options) {
+		return nil, fmt.Errorf("option must be between 0 and %d", len(question.Options)-1)
+	}
+	existing, err := s.store.GetQuestionAnswer(groupID, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("user has already answered this question")
+	}
+
+	answer := &models.QuestionAnswer{
+		ID:         uuid.New().String(),
+		GroupID:    groupID,
+		QuestionID: questionID,
+		UserID:     userID,
+		Option:     option,
+		Correct:    question.CorrectOption != nil && *question.CorrectOption == option,
+		AnsweredAt: time.Now(),
+	}
+	if err := s.store.CreateQuestionAnswer(answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// GiveKudos thanks another member for their help. A member can thank the
+// same person once a day.
+func (s *GroupService) GiveKudos(groupID string, toUserID string, request *models.KudosRequest) (*models.Kudos, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if toUserID == request.ActorID {
+		return nil, fmt.Errorf("members cannot give kudos to themselves")
+	}
+	if !isMember(group, request.ActorID) || !isMember(group, toUserID) {
+		return nil, fmt.Errorf("kudos can only be given between group members")
+	}
+	if err := checkCanPost(group, request.ActorID); err != nil {
+		return nil, err
+	}
+	if request.MessageID != "" {
+		found := false
+		for _, message := range group.Messages {
+			if message.ID == request.MessageID && message.SenderId == toUserID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("message not found for this member")
+		}
+	}
+	reason := strings.TrimSpace(request.Reason)
+	if len(reason) > maxKudosReasonLength {
+		return nil, fmt.Errorf("reason cannot be longer than %d characters", maxKudosReasonLength)
+	}
+
+	now := time.Now()
+	given, err := s.store.GetKudosByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	today := now.UTC().Format(dayFormat)
+	for _, kudos := range given {
+		if kudos.FromUserID == request.ActorID && kudos.ToUserID == toUserID && kudos.CreatedAt.UTC().Format(dayFormat) == today {
+			return nil, fmt.Errorf("kudos already given to this member today")
+		}
+	}
+
+	kudos := &models.Kudos{
+		ID:         uuid.New().String(),
+		GroupID:    groupID,
+		FromUserID: request.ActorID,
+		ToUserID:   toUserID,
+		MessageID:  request.MessageID,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+	if err := s.store.CreateKudos(kudos); err != nil {
+		return nil, err
+	}
+	return kudos, nil
+}
+
+// SetLeaderboardOptOut hides a member from the group's leaderboard, or shows them again
+func (s *GroupService) SetLeaderboardOptOut(groupID string, userID string, optOut bool) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return fmt.Errorf("user is not a member of this group")
+	}
+
+	group.LeaderboardOptOut = removeID(group.LeaderboardOptOut, userID)
+	if optOut {
+		group.LeaderboardOptOut = append(group.LeaderboardOptOut, userID)
+	}
+	return s.store.UpdateGroup(group)
+}
+
+// GetLeaderboard ranks the group's members by contribution: messages sent,
+// questions answered correctly, meetings attended and kudos received for
+// helping others. The weekly window covers the last seven days. Members who
+// opted out are left off, and members with the same score share a rank.
+func (s *GroupService) GetLeaderboard(groupID string, viewerID string, window string) (*models.Leaderboard, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Private && !isMember(group, viewerID) {
+		return nil, fmt.Errorf("only members can see the leaderboard of a private group")
+	}
+
+	leaderboard := &models.Leaderboard{GroupID: groupID, Window: window}
+	var since time.Time
+	switch window {
+	case "", models.LeaderboardAll:
+		leaderboard.Window = models.LeaderboardAll
+	case models.LeaderboardWeek:
+		since = time.Now().AddDate(0, 0, -7)
+		leaderboard.Since = &since
+	default:
+		return nil, fmt.Errorf("window must be %s or %s", models.LeaderboardWeek, models.LeaderboardAll)
+	}
+	counts := func(at time.Time) bool {
+		return !at.Before(since)
+	}
+
+	entries := make(map[string]*models.LeaderboardEntry)
+	for _, memberID := range group.Members {
+		entries[memberID] = &models.LeaderboardEntry{UserID: memberID}
+	}
+	// Only current members are ranked
+	entry := func(userID string) *models.LeaderboardEntry {
+		return entries[userID]
+	}
+
+	for _, message := range group.Messages {
+		if e := entry(message.SenderId); e != nil && isMemberMessage(message) && counts(message.Timestamp) {
+			e.MessagesSent++
+		}
+	}
+	answers, err := s.store.GetQuestionAnswersByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		if e := entry(answer.UserID); e != nil && answer.Correct && counts(answer.AnsweredAt) {
+			e.CorrectAnswers++
+		}
+	}
+	records, err := s.store.GetAttendanceByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if e := entry(record.UserID); e != nil && counts(record.JoinedAt) {
+			e.MeetingsAttended++
+		}
+	}
+	kudos, err := s.store.GetKudosByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, given := range kudos {
+		if e := entry(given.ToUserID); e != nil && counts(given.CreatedAt) {
+			e.HelpGiven++
+		}
+	}
+
+	for _, optedOut := range group.LeaderboardOptOut {
+		delete(entries, optedOut)
+	}
+	leaderboard.Entries = make([]models.LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		e.Score = e.MessagesSent*contributionMessage +
+			e.CorrectAnswers*contributionCorrectAnswer +
+			e.MeetingsAttended*contributionMeeting +
+			e.HelpGiven*contributionHelp
+		leaderboard.Entries = append(leaderboard.Entries, *e)
+	}
+	sort.Slice(leaderboard.Entries, func(i, j int) bool {
+		if leaderboard.Entries[i].Score != leaderboard.Entries[j].Score {
+			return leaderboard.Entries[i].Score > leaderboard.Entries[j].Score
+		}
+		return leaderboard.Entries[i].UserID < leaderboard.Entries[j].UserID
+	})
+	for i := range leaderboard.Entries {
+		if i > 0 && leaderboard.Entries[i].Score == leaderboard.Entries[i-1].Score {
+			leaderboard.Entries[i].Rank = leaderboard.Entries[i-1].Rank
+		} else {
+			leaderboard.Entries[i].Rank = i + 1
+		}
+	}
+	return leaderboard, nil
+}
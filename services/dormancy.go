@@ -0,0 +1,205 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DormancyPolicy decides when a quiet group is flagged as dormant and how
+// long its members then have to keep it open before it is archived. A zero
+// ArchiveAfter leaves dormant groups flagged but never archives them.
+type DormancyPolicy struct {
+	InactiveAfter time.Duration
+	ArchiveAfter  time.Duration
+}
+
+var DefaultDormancyPolicy = DormancyPolicy{
+	InactiveAfter: 7 * 24 * time.Hour,
+	ArchiveAfter:  7 * 24 * time.Hour,
+}
+
+// GetDormancyPolicy returns the dormancy policy in hours
+func (s *GroupService) GetDormancyPolicy() models.DormancySettings {
+	return models.DormancySettings{
+		InactiveAfterHours: s.dormancyPolicy.InactiveAfter.Hours(),
+		ArchiveAfterHours:  s.dormancyPolicy.ArchiveAfter.Hours(),
+	}
+}
+
+// SetDormancyPolicy changes when quiet groups go dormant and are archived
+// (admin only). The next dormant-groups run applies it.
+func (s *GroupService) SetDormancyPolicy(actorID string, settings models.DormancySettings) error {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return err
+	}
+	if settings.InactiveAfterHours <= 0 {
+		return fmt.Errorf("inactive period must be a positive number of hours")
+	}
+	if settings.ArchiveAfterHours < 0 {
+		return fmt.Errorf("archive period cannot be negative")
+	}
+	s.dormancyPolicy = DormancyPolicy{
+		InactiveAfter: hoursToDuration(settings.InactiveAfterHours),
+		ArchiveAfter:  hoursToDuration(settings.ArchiveAfterHours),
+	}
+	return nil
+}
+
+// ApplyDormancyPolicy flags groups nobody has done anything in for a while,
+// nudges their members, wakes groups that became active again, and archives
+// groups that stayed dormant for too long. Dormant groups are left out of
+// search and recommendations.
+func (s *GroupService) ApplyDormancyPolicy() error {
+	if s.dormancyPolicy.InactiveAfter <= 0 {
+		return nil
+	}
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		// Empty groups are handled by the empty-group policy, and breakouts
+		// are closed from their main group
+		if group.Archived || group.ParentID != "" || len(group.Members) == 0 {
+			continue
+		}
+		lastActive, err := s.lastActivity(group)
+		if err != nil {
+			return err
+		}
+
+		if group.DormantSince != nil {
+			if lastActive.After(*group.DormantSince) {
+				if err := s.wakeGroup(group); err != nil {
+					return err
+				}
+				continue
+			}
+			if s.dormancyPolicy.ArchiveAfter > 0 && now.Sub(*group.DormantSince) >= s.dormancyPolicy.ArchiveAfter {
+				if err := s.archiveGroup(group, "system", now, "The meeting ended because the group was archived"); err != nil {
+					return err
+				}
+				if err := s.postSystemMessage(group.ID, "This group was archived after staying quiet"); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if now.Sub(lastActive) < s.dormancyPolicy.InactiveAfter {
+			continue
+		}
+		group.DormantSince = &now
+		if err := s.store.UpdateGroup(group); err != nil {
+			return err
+		}
+		nudge := fmt.Sprintf("This group has been quiet for %d days and no longer shows up in search.", int(now.Sub(lastActive).Hours()/24))
+		if s.dormancyPolicy.ArchiveAfter > 0 {
+			nudge += fmt.Sprintf(" It will be archived on %s unless someone posts, meets or asks to keep it open.", now.Add(s.dormancyPolicy.ArchiveAfter).Format("2 Jan 2006"))
+		} else {
+			nudge += " Post a message or start a meeting to bring it back."
+		}
+		if err := s.postSystemMessage(group.ID, nudge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KeepGroupOpen lets a member object to a dormant group being archived. The
+// group comes back into search and gets another full period before it can
+// be flagged again.
+func (s *GroupService) KeepGroupOpen(groupID string, userID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if group.Archived {
+		return fmt.Errorf("group is archived")
+	}
+	if !isMember(group, userID) {
+		return fmt.Errorf("only group members can keep a group open")
+	}
+
+	now := time.Now()
+	group.KeptOpenAt = &now
+	if group.DormantSince == nil {
+		return s.store.UpdateGroup(group)
+	}
+	group.DormantSince = nil
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+	return s.postSystemMessage(groupID, fmt.Sprintf("%s asked to keep the group open", userID))
+}
+
+// wakeGroup clears the dormant flag once members are active again
+func (s *GroupService) wakeGroup(group *models.Group) error {
+	if group.DormantSince == nil {
+		return nil
+	}
+	group.DormantSince = nil
+	return s.store.UpdateGroup(group)
+}
+
+// lastActivity is the latest of the group's creation, member messages,
+// actions, meetings, joins and requests to keep it open
+func (s *GroupService) lastActivity(group *models.Group) (time.Time, error) {
+	latest := group.CreatedAt
+	later := func(at time.Time) {
+		if at.After(latest) {
+			latest = at
+		}
+	}
+
+	for _, message := range group.Messages {
+		if isMemberMessage(message) {
+			later(message.Timestamp)
+		}
+	}
+	for _, action := range group.Actions {
+		later(action.Timestamp)
+	}
+	if group.KeptOpenAt != nil {
+		later(*group.KeptOpenAt)
+	}
+
+	meetings, err := s.store.GetMeetingsByGroup(group.ID)
+	if err != nil {
+		return latest, err
+	}
+	for _, meeting := range meetings {
+		later(meeting.StartedAt)
+		if meeting.EndedAt == nil {
+			later(time.Now())
+		}
+	}
+	events, err := s.store.GetMembershipEvents(group.ID)
+	if err != nil {
+		return latest, err
+	}
+	for _, event := range events {
+		if event.Type == models.MembershipJoined {
+			later(event.Timestamp)
+		}
+	}
+	return latest, nil
+}
+
+func (s *GroupService) postSystemMessage(groupID string, content string) error {
+	message := models.Message{
+		ID:        uuid.New().String(),
+		Content:   content,
+		SenderId:  "system",
+		Timestamp: time.Now(),
+	}
+	return s.addMessage(groupID, &message)
+}
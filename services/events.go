@@ -0,0 +1,251 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// subscriptionBuffer is how many events a subscriber can fall behind by
+	// before it is dropped
+	subscriptionBuffer = 64
+	// replaySize is how many recent events are kept for clients resuming a stream
+	replaySize = 1000
+)
+
+// EventBroker fans group events out to live subscribers and keeps the most
+// recent ones so that clients can resume where they left off. Publishing
+// never blocks: a subscriber that falls too far behind is dropped and its
+// channel closed.
+type EventBroker struct {
+	mu sync.Mutex
+	// epoch tells this run's stream IDs apart from those handed out before
+	// a restart, when numbering starts again
+	epoch       string
+	lastID      int64
+	history     []models.Event
+	subscribers map[*Subscription]bool
+}
+
+// Subscription receives the events its filter accepts until it is
+// unsubscribed or dropped, at which point Events is closed
+type Subscription struct {
+	Events <-chan models.Event
+	events chan models.Event
+	filter func(models.Event) bool
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		epoch:       strconv.FormatInt(time.Now().UnixMilli(), 10),
+		subscribers: make(map[*Subscription]bool),
+	}
+}
+
+// StreamID is the ID clients see for an event: "<epoch>-<number>"
+func (b *EventBroker) StreamID(event models.Event) string {
+	return fmt.Sprintf("%s-%d", b.epoch, event.ID)
+}
+
+// ParseStreamID returns the event number in a stream ID. current is false
+// when the ID was handed out before a restart, bare numbers included.
+func (b *EventBroker) ParseStreamID(streamID string) (id int64, current bool, err error) {
+	epoch, number, found := strings.Cut(streamID, "-")
+	if !found {
+		epoch, number = "", streamID
+	}
+	id, err = strconv.ParseInt(number, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, fmt.Errorf("invalid event ID")
+	}
+	if epoch != b.epoch {
+		return 0, false, nil
+	}
+	return id, true, nil
+}
+
+func (b *EventBroker) Subscribe(filter func(models.Event) bool) *Subscription {
+	_, subscription, _ := b.SubscribeSince(0, filter)
+	return subscription
+}
+
+// SubscribeSince subscribes and returns the events published after lastID
+// that are still kept. complete is false when some of them have already
+// been dropped, or lastID is ahead of this run. A lastID of 0 starts from
+// now.
+func (b *EventBroker) SubscribeSince(lastID int64, filter func(models.Event) bool) (replay []models.Event, subscription *Subscription, complete bool) {
+	events := make(chan models.Event, subscriptionBuffer)
+	subscription = &Subscription{Events: events, events: events, filter: filter}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[subscription] = true
+	if lastID == 0 {
+		return nil, subscription, true
+	}
+
+	complete = lastID <= b.lastID
+	if len(b.history) > 0 && lastID < b.history[0].ID-1 {
+		complete = false
+	}
+	for _, event := range b.history {
+		if event.ID > lastID && filter(event) {
+			replay = append(replay, event)
+		}
+	}
+	return replay, subscription, complete
+}
+
+// Unsubscribe stops a subscription. It is safe to call more than once.
+func (b *EventBroker) Unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[subscription] {
+		delete(b.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+// Publish numbers the event and hands it to every interested subscriber
+func (b *EventBroker) Publish(event models.Event) models.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	b.history = append(b.history, event)
+	if len(b.history) > replaySize {
+		b.history = b.history[len(b.history)-replaySize:]
+	}
+	for subscription := range b.subscribers {
+		if !subscription.filter(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			delete(b.subscribers, subscription)
+			close(subscription.events)
+		}
+	}
+	return event
+}
+
+// SubscribeToGroup streams a group's events to one of its members
+func (s *GroupService) SubscribeToGroup(groupID string, userID string) (*Subscription, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can follow the group")
+	}
+
+	return s.events.Subscribe(func(event models.Event) bool {
+		return event.GroupID == groupID
+	}), nil
+}
+
+// FollowUser streams the events of every group the user is a member of,
+// including their own joins and leaves, and changes to their
+// recommendations. Events after lastEventID that are still kept come first.
+func (s *GroupService) FollowUser(userID string, lastEventID int64) ([]models.Event, *Subscription, bool, error) {
+	user, err := s.store.GetUser(userID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if user == nil {
+		return nil, nil, false, fmt.Errorf("user not found")
+	}
+
+	replay, subscription, complete := s.events.SubscribeSince(lastEventID, func(event models.Event) bool {
+		if event.UserID != "" {
+			return event.UserID == userID
+		}
+		if membership, ok := event.Data.(models.MembershipEvent); ok && membership.UserID == userID {
+			return true
+		}
+		group, err := s.store.GetGroup(event.GroupID)
+		return err == nil && group != nil && isMember(group, userID)
+	})
+	return replay, subscription, complete, nil
+}
+
+// EventStreamID and ParseEventStreamID convert between events and the IDs
+// streams resume from
+func (s *GroupService) EventStreamID(event models.Event) string {
+	return s.events.StreamID(event)
+}
+
+func (s *GroupService) ParseEventStreamID(streamID string) (int64, bool, error) {
+	return s.events.ParseStreamID(streamID)
+}
+
+func (s *GroupService) Unsubscribe(subscription *Subscription) {
+	s.events.Unsubscribe(subscription)
+}
+
+// SendMessage posts a member's chat message through the same path as
+// UpdateGroup
+func (s *GroupService) SendMessage(groupID string, userID string, content string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return fmt.Errorf("only group members can send messages")
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("message cannot be empty")
+	}
+
+	return s.UpdateGroup(groupID, &models.GroupUpdateRequest{
+		Message: &models.MessageUpdate{
+			Content:  content,
+			SenderID: userID,
+		},
+	})
+}
+
+// addMessage stores a message and tells the group's subscribers about it
+func (s *GroupService) addMessage(groupID string, message *models.Message) error {
+	if err := s.store.AddMessageToGroup(groupID, message); err != nil {
+		return err
+	}
+	s.publish(groupID, models.EventMessage, *message)
+	return nil
+}
+
+func (s *GroupService) addAction(groupID string, action *models.Action) error {
+	if err := s.store.AddActionToGroup(groupID, action); err != nil {
+		return err
+	}
+	s.publish(groupID, models.EventAction, *action)
+	return nil
+}
+
+func (s *GroupService) publish(groupID string, eventType string, data any) {
+	s.events.Publish(models.Event{Type: eventType, GroupID: groupID, Data: data})
+}
+
+// recommendationsChanged tells the user's feed their recommended groups changed
+func (s *GroupService) recommendationsChanged(userGroup *models.UserGroup) {
+	s.events.Publish(models.Event{
+		Type:   models.EventRecommendationsChanged,
+		UserID: userGroup.UserID,
+		Data:   append([]string{}, userGroup.RecommendedGroups...),
+	})
+}
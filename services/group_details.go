@@ -0,0 +1,191 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 1000
+)
+
+// PatchGroup edits a group's details on behalf of its owner or a moderator
+// and records every field that changed
+func (s *GroupService) PatchGroup(groupID string, patch *models.GroupPatchRequest) (*models.Group, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, patch.ActorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can edit group details")
+	}
+
+	// Validate everything before touching the group so a bad field changes nothing
+	updated := *group
+	if patch.Title != nil {
+		title := strings.TrimSpace(*patch.Title)
+		if title == "" {
+			return nil, fmt.Errorf("title cannot be empty")
+		}
+		if len(title) > maxTitleLength {
+			return nil, fmt.Errorf("title cannot be longer than %d characters", maxTitleLength)
+		}
+		updated.Title = title
+	}
+	if patch.Description != nil {
+		description := strings.TrimSpace(*patch.Description)
+		if len(description) > maxDescriptionLength {
+			return nil, fmt.Errorf("description cannot be longer than %d characters", maxDescriptionLength)
+		}
+		updated.Description = description
+	}
+	if patch.Tag != nil {
+		tag, err := canonicalTag(s.store, *patch.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" {
+			return nil, fmt.Errorf("tag cannot be empty")
+		}
+		updated.Tag = tag
+	}
+	if patch.Tags != nil {
+		tags, err := validateTaxonomyTags(s.store, *patch.Tags)
+		if err != nil {
+			return nil, err
+		}
+		updated.Tags = tags
+	}
+	groupType := s.groupTypes.Lookup(group.Type)
+	if patch.Type != nil {
+		groupType = s.groupTypes.Lookup(*patch.Type)
+		if groupType == nil {
+			return nil, fmt.Errorf("unknown group type %q", *patch.Type)
+		}
+		if groupType.Name != group.Type {
+			switch groupType.Creators {
+			case models.CreatorsSystem:
+				return nil, fmt.Errorf("groups cannot be changed to %s", groupType.Name)
+			case models.CreatorsAdmin:
+				if err := requireAdmin(s.store, patch.ActorID); err != nil {
+					return nil, fmt.Errorf("only admins can change groups to %s", groupType.Name)
+				}
+			}
+		}
+		updated.Type = groupType.Name
+	}
+	if patch.Capacity != nil {
+		if *patch.Capacity < 1 {
+			return nil, fmt.Errorf("capacity must be at least 1")
+		}
+		if *patch.Capacity < len(group.Members) {
+			return nil, fmt.Errorf("capacity cannot be lower than the current member count of %d", len(group.Members))
+		}
+		updated.Capacity = *patch.Capacity
+	}
+	if patch.Private != nil {
+		updated.Private = *patch.Private
+	}
+	if patch.JoinPolicy != nil {
+		if !validJoinPolicy(*patch.JoinPolicy) {
+			return nil, fmt.Errorf("join policy must be %s, %s or %s", models.JoinPolicyOpen, models.JoinPolicyApproval, models.JoinPolicyInviteOnly)
+		}
+		updated.JoinPolicy = *patch.JoinPolicy
+	}
+
+	// Whatever changed, the group must still follow its type's rules
+	if groupType != nil {
+		if patch.Type != nil || patch.Capacity != nil {
+			if err := checkCapacity(groupType, updated.Capacity); err != nil {
+				return nil, err
+			}
+		}
+		if patch.Type != nil || patch.JoinPolicy != nil || patch.Private != nil {
+			if err := checkJoinPolicy(groupType, s.joinPolicy(&updated)); err != nil {
+				return nil, err
+			}
+		}
+		// The lifespan belongs to the type, counted from when the group was made
+		if patch.Type != nil || patch.Private != nil {
+			updated.ExpiresAt = expiresAt(groupType, group.CreatedAt)
+		}
+	}
+
+	now := time.Now()
+	changes := []*models.GroupChange{}
+	record := func(field, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		changes = append(changes, &models.GroupChange{
+			ID:        uuid.New().String(),
+			GroupID:   groupID,
+			ChangedBy: patch.ActorID,
+			Field:     field,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			Timestamp: now,
+		})
+	}
+	record("title", group.Title, updated.Title)
+	record("description", group.Description, updated.Description)
+	record("tag", group.Tag, updated.Tag)
+	record("tags", strings.Join(group.Tags, ","), strings.Join(updated.Tags, ","))
+	record("type", group.Type, updated.Type)
+	record("capacity", strconv.Itoa(group.Capacity), strconv.Itoa(updated.Capacity))
+	record("private", strconv.FormatBool(group.Private), strconv.FormatBool(updated.Private))
+	record("joinPolicy", group.JoinPolicy, updated.JoinPolicy)
+	if len(changes) == 0 {
+		return group, nil
+	}
+
+	capacityRaised := updated.Capacity > group.Capacity
+	*group = updated
+	if err := s.store.UpdateGroup(group); err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		if err := s.store.AddGroupChange(change); err != nil {
+			return nil, err
+		}
+	}
+
+	// New seats go to the people waiting for them
+	if capacityRaised {
+		if err := s.admitFromWaitlist(groupID); err != nil {
+			return nil, err
+		}
+	}
+	return group, nil
+}
+
+// GetGroupHistory returns the edit history of a group to its members
+func (s *GroupService) GetGroupHistory(groupID string, userID string, page models.PageRequest) (*models.Page[*models.GroupChange], error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) && !canManageGroup(group, userID) {
+		return nil, fmt.Errorf("only group members can view the group history")
+	}
+
+	changes, err := s.store.GetGroupChanges(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(changes, "created", false, func(change *models.GroupChange) (int64, string) {
+		return change.Timestamp.UnixNano(), change.ID
+	}, page)
+}
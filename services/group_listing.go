@@ -0,0 +1,129 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"math"
+)
+
+// groupSortKey returns the key groups are ordered by, highest first, for a sort option
+func groupSortKey(sortName string) (func(*models.Group) (int64, string), error) {
+	switch sortName {
+	case models.GroupSortActivity:
+		return func(group *models.Group) (int64, string) {
+			return int64(group.ActivityScore), group.ID
+		}, nil
+	case models.GroupSortNewest:
+		return func(group *models.Group) (int64, string) {
+			return group.CreatedAt.UnixNano(), group.ID
+		}, nil
+	case models.GroupSortMembers:
+		return func(group *models.Group) (int64, string) {
+			return int64(len(group.Members)), group.ID
+		}, nil
+	case models.GroupSortFreeSeats:
+		return func(group *models.Group) (int64, string) {
+			return int64(group.Capacity - len(group.Members)), group.ID
+		}, nil
+	default:
+		return nil, fmt.Errorf("sort must be one of %s, %s, %s or %s", models.GroupSortActivity, models.GroupSortNewest, models.GroupSortMembers, models.GroupSortFreeSeats)
+	}
+}
+
+func matchesGroupFilters(group *models.Group, options models.GroupListOptions) bool {
+	if options.Type != "" && group.Type != options.Type {
+		return false
+	}
+	if options.Private != nil && group.Private != *options.Private {
+		return false
+	}
+	if options.MinFreeSeats > 0 && group.Capacity-len(group.Members) < options.MinFreeSeats {
+		return false
+	}
+	if options.CreatedBy != "" && group.CreateBy != options.CreatedBy {
+		return false
+	}
+	return true
+}
+
+// ListGroups filters, sorts and pages groups. Groups are sorted by activity
+// unless another sort is asked for.
+func (s *GroupService) ListGroups(groups []*models.Group, options models.GroupListOptions) (*models.Page[*models.Group], error) {
+	if options.All {
+		options.All = false
+		items, err := allPages(func(page models.PageRequest) (*models.Page[*models.Group], error) {
+			options.PageRequest = page
+			return s.ListGroups(groups, options)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &models.Page[*models.Group]{Items: items}, nil
+	}
+	if options.Sort == "" {
+		options.Sort = models.GroupSortActivity
+	}
+	key, err := groupSortKey(options.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*models.Group{}
+	for _, group := range groups {
+		if matchesGroupFilters(group, options) {
+			filtered = append(filtered, group)
+		}
+	}
+	return paginate(filtered, options.Sort, true, key, options.PageRequest)
+}
+
+// ListSearchResults filters, sorts and pages full-text search results. They
+// stay in relevance order unless another sort is asked for.
+func (s *GroupService) ListSearchResults(results []*models.GroupSearchResult, options models.GroupListOptions) (*models.Page[*models.GroupSearchResult], error) {
+	if options.Sort == "" {
+		options.Sort = models.GroupSortRelevance
+	}
+
+	var key func(*models.GroupSearchResult) (int64, string)
+	if options.Sort == models.GroupSortRelevance {
+		// Scores are fractions, so keep six decimal places in the integer key
+		key = func(result *models.GroupSearchResult) (int64, string) {
+			return int64(math.Round(result.Score * 1e6)), result.Group.ID
+		}
+	} else {
+		groupKey, err := groupSortKey(options.Sort)
+		if err != nil {
+			return nil, fmt.Errorf("%v or %s", err, models.GroupSortRelevance)
+		}
+		key = func(result *models.GroupSearchResult) (int64, string) {
+			return groupKey(result.Group)
+		}
+	}
+
+	filtered := []*models.GroupSearchResult{}
+	for _, result := range results {
+		if matchesGroupFilters(result.Group, options) {
+			filtered = append(filtered, result)
+		}
+	}
+	return paginate(filtered, options.Sort, true, key, options.PageRequest)
+}
+
+func (s *GroupService) SearchGroupsByTag(tag string, userID string, options models.GroupListOptions) (*models.Page[*models.Group], error) {
+	return s.ListGroups(s.store.SearchGroupsByTag(tag, userID), options)
+}
+
+// SearchAllGroupsByTag returns every match of a tag search, sorted and
+// filtered like the paged search, as one list
+func (s *GroupService) SearchAllGroupsByTag(tag string, userID string, options models.GroupListOptions) ([]*models.Group, error) {
+	options.All = true
+	page, err := s.ListGroups(s.store.SearchGroupsByTag(tag, userID), options)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+func (s *GroupService) SearchGroups(query string, userID string, options models.GroupListOptions) (*models.Page[*models.GroupSearchResult], error) {
+	return s.ListSearchResults(s.store.SearchGroups(query, userID), options)
+}
@@ -10,35 +10,91 @@ import (
 )
 
 type GroupService struct {
-	store storage.Store
+	store                 storage.Store
+	waitlistConfirmWindow time.Duration
+	emptyGroupPolicy      EmptyGroupPolicy
+	activityWeights       models.ActivityWeights
+	dormancyPolicy        DormancyPolicy
+	groupTypes            *GroupTypeRegistry
+	events                *EventBroker
 }
 
 func NewGroupService(store storage.Store) *GroupService {
 	return &GroupService{
-		store: store,
+		store:                 store,
+		waitlistConfirmWindow: DefaultWaitlistConfirmWindow,
+		emptyGroupPolicy:      DefaultEmptyGroupPolicy,
+		activityWeights:       DefaultActivityWeights,
+		dormancyPolicy:        DefaultDormancyPolicy,
+		groupTypes:            NewGroupTypeRegistry(DefaultGroupTypes()...),
+		events:                NewEventBroker(),
 	}
 }
 
+const defaultWelcomeMessage = "Welcome to the group!"
+
 func (s *GroupService) CreateGroup(group *models.Group) error {
+	return s.createGroup(group, defaultWelcomeMessage)
+}
+
+// createGroup creates a group that opens with the given welcome message
+func (s *GroupService) createGroup(group *models.Group, welcome string) error {
+	// Only what the creator chooses is kept. Breakout, merge, archive,
+	// meeting, moderation and lifecycle state is the server's to set.
+	*group = models.Group{
+		Title:       group.Title,
+		Description: group.Description,
+		Tag:         group.Tag,
+		Tags:        group.Tags,
+		Type:        group.Type,
+		Private:     group.Private,
+		JoinPolicy:  group.JoinPolicy,
+		Capacity:    group.Capacity,
+		Questions:   group.Questions,
+		CreateBy:    group.CreateBy,
+	}
+
 	// Generate a new UUID for the group
 	group.ID = uuid.New().String()
+	group.CreatedAt = time.Now()
 
 	// Set initial values
 	group.Messages = make([]models.Message, 0)
 	group.Messages = append(group.Messages, models.Message{
 		ID:        uuid.New().String(),
-		Content:   "Welcome to the group!",
+		Content:   welcome,
 		SenderId:  "system",
 		Timestamp: time.Now(),
 	})
 	group.ActivityScore = 0
 
-	group.Members = append(group.Members, group.CreateBy)
+	// The group's type decides capacity, join policy and lifespan
+	if err := s.applyGroupType(group); err != nil {
+		return err
+	}
+	group.Moderators = []string{}
+
+	tag, err := canonicalTag(s.store, group.Tag)
+	if err != nil {
+		return err
+	}
+	group.Tag = tag
+
+	tags, err := validateTaxonomyTags(s.store, group.Tags)
+	if err != nil {
+		return err
+	}
+	group.Tags = tags
+
+	group.Members = []string{group.CreateBy}
 
 	// Store the group
 	if err := s.store.CreateGroup(group); err != nil {
 		return err
 	}
+	if err := s.recordMembership(group.ID, group.CreateBy, models.MembershipJoined); err != nil {
+		return err
+	}
 
 	// Get user's group data
 	userGroup, err := s.store.GetUserGroup(group.CreateBy)
@@ -62,7 +118,9 @@ func (s *GroupService) CreateGroup(group *models.Group) error {
 	return s.store.UpdateUserGroup(userGroup)
 }
 
-func (s *GroupService) GetGroupsPage(userID string) (*models.GroupsPageResponse, error) {
+// GetGroupsPage returns the user's active and recommended groups, each list
+// sorted, filtered and paged by its own options
+func (s *GroupService) GetGroupsPage(userID string, activeOptions models.GroupListOptions, recommendedOptions models.GroupListOptions) (*models.GroupsPageResponse, error) {
 	// Get user's group data
 	userGroup, err := s.store.GetUserGroup(userID)
 	if err != nil {
@@ -89,20 +147,36 @@ func (s *GroupService) GetGroupsPage(userID string) (*models.GroupsPageResponse,
 		return nil, err
 	}
 
-	// Get recommended groups
+	// Get recommended groups, leaving out dormant ones until they wake up
 	recommendedGroups, err := s.store.GetGroupsByIDs(userGroup.RecommendedGroups)
 	if err != nil {
 		return nil, err
 	}
+	awakeGroups := []*models.Group{}
+	for _, group := range recommendedGroups {
+		if group.DormantSince == nil {
+			awakeGroups = append(awakeGroups, group)
+		}
+	}
+	recommendedGroups = awakeGroups
+
+	activePage, err := s.ListGroups(activeGroups, activeOptions)
+	if err != nil {
+		return nil, err
+	}
+	recommendedPage, err := s.ListGroups(recommendedGroups, recommendedOptions)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert []*models.Group to []models.Group
-	activeGroupsList := make([]models.Group, len(activeGroups))
-	for i, group := range activeGroups {
+	activeGroupsList := make([]models.Group, len(activePage.Items))
+	for i, group := range activePage.Items {
 		activeGroupsList[i] = *group
 	}
 
-	recommendedGroupsList := make([]models.Group, len(recommendedGroups))
-	for i, group := range recommendedGroups {
+	recommendedGroupsList := make([]models.Group, len(recommendedPage.Items))
+	for i, group := range recommendedPage.Items {
 		recommendedGroupsList[i] = *group
 	}
 
@@ -110,15 +184,28 @@ func (s *GroupService) GetGroupsPage(userID string) (*models.GroupsPageResponse,
 		SystemRecommendedGroups: recommendedGroupsList,
 		UserActiveGroups:        activeGroupsList,
 		User:                    *user,
+		ActiveNextCursor:        activePage.NextCursor,
+		RecommendedNextCursor:   recommendedPage.NextCursor,
 	}, nil
 }
 
+// GetGroup returns a group with any expired announcements taken down
 func (s *GroupService) GetGroup(id string) (*models.Group, error) {
 	group, err := s.store.GetGroup(id)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	// Expired announcements are hidden here and taken down by the
+	// expired-announcements job, so reading never changes the group
+	response := *group
+	response.Announcements = currentAnnouncements(group, time.Now())
+	// The open meeting is the source of truth for whether one is running
+	meeting, err := s.store.GetOpenMeeting(id)
 	if err != nil {
 		return nil, err
 	}
-	return group, nil
+	response.MeetingStarted = meeting != nil
+	return &response, nil
 }
 
 func (s *GroupService) JoinGroup(groupID string, userID string) error {
@@ -130,6 +217,18 @@ func (s *GroupService) JoinGroup(groupID string, userID string) error {
 	if group == nil {
 		return fmt.Errorf("group not found")
 	}
+	if group.Archived {
+		return fmt.Errorf("group is archived")
+	}
+	if group.ParentID != "" {
+		return fmt.Errorf("breakouts are set up from their main group")
+	}
+	if isBanned(group, userID) {
+		return fmt.Errorf("user is banned from this group")
+	}
+	if hasExpired(group, time.Now()) {
+		return fmt.Errorf("group has expired")
+	}
 
 	// Check capacity
 	if len(group.Members) >= group.Capacity {
@@ -148,6 +247,16 @@ func (s *GroupService) JoinGroup(groupID string, userID string) error {
 		return err
 	}
 
+	if group.EmptySince != nil {
+		group.EmptySince = nil
+		if err := s.store.UpdateGroup(group); err != nil {
+			return err
+		}
+	}
+	if err := s.recordMembership(groupID, userID, models.MembershipJoined); err != nil {
+		return err
+	}
+
 	// Get user's group data
 	userGroup, err := s.store.GetUserGroup(userID)
 	if err != nil {
@@ -183,13 +292,20 @@ func (s *GroupService) JoinGroup(groupID string, userID string) error {
 			recommendedGroups = append(recommendedGroups, recGroupID)
 		}
 	}
+	recommendationsChanged := len(recommendedGroups) != len(userGroup.RecommendedGroups)
 	userGroup.RecommendedGroups = recommendedGroups
 
 	// Save or update user group data
 	if userGroup.ID == "" {
 		return s.store.CreateUserGroup(userGroup)
 	}
-	return s.store.UpdateUserGroup(userGroup)
+	if err := s.store.UpdateUserGroup(userGroup); err != nil {
+		return err
+	}
+	if recommendationsChanged {
+		s.recommendationsChanged(userGroup)
+	}
+	return nil
 }
 
 func (s *GroupService) LeaveGroup(groupID string, userID string) error {
@@ -214,10 +330,43 @@ func (s *GroupService) LeaveGroup(groupID string, userID string) error {
 		return fmt.Errorf("user is not a member of this group")
 	}
 
+	// Leaving the group also means leaving its meeting and breakouts
+	if err := s.leaveMeetingIfPresent(groupID, userID); err != nil {
+		return err
+	}
+	if err := s.leaveBreakouts(groupID, userID); err != nil {
+		return err
+	}
+
 	// Remove user from group members
 	if err := s.store.RemoveMemberFromGroup(groupID, userID); err != nil {
 		return err
 	}
+	if err := s.recordMembership(groupID, userID, models.MembershipLeft); err != nil {
+		return err
+	}
+	if group, err = s.store.GetGroup(groupID); err != nil {
+		return err
+	}
+
+	// Moderator rights end with membership
+	if isModerator(group, userID) {
+		group.Moderators = removeID(group.Moderators, userID)
+		if err := s.store.UpdateGroup(group); err != nil {
+			return err
+		}
+	}
+
+	// Hand the group over if its owner leaves, or start the empty-group clock
+	if len(group.Members) == 0 {
+		now := time.Now()
+		group.EmptySince = &now
+		if err := s.store.UpdateGroup(group); err != nil {
+			return err
+		}
+	} else if err := s.handOverOwnership(group, userID); err != nil {
+		return err
+	}
 
 	// Get user's group data
 	userGroup, err := s.store.GetUserGroup(userID)
@@ -238,7 +387,24 @@ func (s *GroupService) LeaveGroup(groupID string, userID string) error {
 	userGroup.ActiveGroups = activeGroups
 
 	// Update user group data
-	return s.store.UpdateUserGroup(userGroup)
+	if err := s.store.UpdateUserGroup(userGroup); err != nil {
+		return err
+	}
+
+	// An unconfirmed seat from the waitlist is given up along with the membership
+	position, err := s.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		return err
+	}
+	if position.Entry != nil && position.Entry.Status == models.WaitlistAdmitted {
+		position.Entry.Status = models.WaitlistLeft
+		if err := s.store.UpdateWaitlistEntry(position.Entry); err != nil {
+			return err
+		}
+	}
+
+	// Offer the freed seat to the next person in line
+	return s.admitFromWaitlist(groupID)
 }
 
 func (s *GroupService) UpdateGroup(groupID string, update *models.GroupUpdateRequest) error {
@@ -251,15 +417,44 @@ func (s *GroupService) UpdateGroup(groupID string, update *models.GroupUpdateReq
 		return fmt.Errorf("group not found")
 	}
 
+	// Only current members can post, and timed out members can read but
+	// not post
+	if update.Message != nil {
+		if update.Message.SenderID == "" {
+			return fmt.Errorf("sender_id is required to post a message")
+		}
+		if !isMember(group, update.Message.SenderID) || isBanned(group, update.Message.SenderID) {
+			return fmt.Errorf("only group members can send messages")
+		}
+		if err := checkCanPost(group, update.Message.SenderID); err != nil {
+			return err
+		}
+	}
+	if update.Action != nil {
+		if update.UserID == "" {
+			return fmt.Errorf("user_id is required to post an action")
+		}
+		if !isMember(group, update.UserID) || isBanned(group, update.UserID) {
+			return fmt.Errorf("only group members can post actions")
+		}
+		if err := checkCanPost(group, update.UserID); err != nil {
+			return err
+		}
+	}
+
+	// Posts are stamped with the server's clock so a client cannot backdate
+	// or future-date activity
+	now := time.Now()
+
 	// Handle message update
 	if update.Message != nil {
 		message := models.Message{
 			ID:        uuid.New().String(),
 			Content:   update.Message.Content,
 			SenderId:  update.Message.SenderID,
-			Timestamp: update.Message.Timestamp,
+			Timestamp: now,
 		}
-		if err := s.store.AddMessageToGroup(groupID, &message); err != nil {
+		if err := s.addMessage(groupID, &message); err != nil {
 			return err
 		}
 	}
@@ -271,12 +466,12 @@ func (s *GroupService) UpdateGroup(groupID string, update *models.GroupUpdateReq
 			ID:        uuid.New().String(),
 			Type:      update.Action.Type,
 			Content:   update.Action.Content,
-			SenderId:  "system",
-			Timestamp: update.Action.Timestamp,
+			SenderId:  update.UserID,
+			Timestamp: now,
 		}
 
 		// Add action to group
-		if err := s.store.AddActionToGroup(groupID, &action); err != nil {
+		if err := s.addAction(groupID, &action); err != nil {
 			return err
 		}
 
@@ -284,16 +479,49 @@ func (s *GroupService) UpdateGroup(groupID string, update *models.GroupUpdateReq
 		actionMessage := models.Message{
 			ID:        uuid.New().String(),
 			Content:   fmt.Sprintf("[%s] %s", update.Action.Type, update.Action.Content),
-			SenderId:  "system",
-			Timestamp: update.Action.Timestamp,
+			SenderId:  update.UserID,
+			Timestamp: now,
+			ActionID:  action.ID,
 		}
 
 		// Add the action message
-		if err := s.store.AddMessageToGroup(groupID, &actionMessage); err != nil {
+		if err := s.addMessage(groupID, &actionMessage); err != nil {
+			return err
+		}
+	}
+
+	if update.Message != nil || update.Action != nil {
+		if err := s.refreshActivityScore(groupID); err != nil {
 			return err
 		}
 	}
 
+	// Handle meeting start or end
+	if update.MeetingStarted != nil {
+		userID := update.UserID
+		if userID == "" && update.Message != nil {
+			userID = update.Message.SenderID
+		}
+		if userID == "" {
+			return fmt.Errorf("user_id is required to start or end a meeting")
+		}
+
+		open, err := s.store.GetOpenMeeting(groupID)
+		if err != nil {
+			return err
+		}
+		// Asking for the state the group is already in changes nothing
+		if *update.MeetingStarted && open == nil {
+			if _, err := s.StartMeeting(groupID, userID, ""); err != nil {
+				return err
+			}
+		} else if !*update.MeetingStarted && open != nil {
+			if _, err := s.EndMeeting(groupID, userID); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -314,8 +542,67 @@ func (s *GroupService) RejectGroupRecommendation(groupID string, userID string)
 			recommendedGroups = append(recommendedGroups, recGroupID)
 		}
 	}
+	recommendationsChanged := len(recommendedGroups) != len(userGroup.RecommendedGroups)
 	userGroup.RecommendedGroups = recommendedGroups
 
+	// Remember the rejection so it is not recommended again
+	isRejected := false
+	for _, rejectedGroupID := range userGroup.RejectedGroups {
+		if rejectedGroupID == groupID {
+			isRejected = true
+			break
+		}
+	}
+	if !isRejected {
+		userGroup.RejectedGroups = append(userGroup.RejectedGroups, groupID)
+	}
+
 	// Update user group data
-	return s.store.UpdateUserGroup(userGroup)
+	if err := s.store.UpdateUserGroup(userGroup); err != nil {
+		return err
+	}
+	if recommendationsChanged {
+		s.recommendationsChanged(userGroup)
+	}
+	return nil
+}
+
+func isMember(group *models.Group, userID string) bool {
+	for _, memberID := range group.Members {
+		if memberID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// isMemberMessage reports whether a message is a member's own chat message,
+// rather than a system notice or the announcement of an action
+func isMemberMessage(message models.Message) bool {
+	return message.SenderId != "system" && message.ActionID == ""
+}
+
+func isModerator(group *models.Group, userID string) bool {
+	for _, moderatorID := range group.Moderators {
+		if moderatorID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// canManageGroup reports whether the user is the group owner or one of its moderators
+func canManageGroup(group *models.Group, userID string) bool {
+	return userID != "" && (group.CreateBy == userID || isModerator(group, userID))
+}
+
+// removeID returns ids without the given id
+func removeID(ids []string, id string) []string {
+	remaining := []string{}
+	for _, existing := range ids {
+		if existing != id {
+			remaining = append(remaining, existing)
+		}
+	}
+	return remaining
 }
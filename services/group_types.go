@@ -0,0 +1,180 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultGroupTypes are the group types the platform ships with
+func DefaultGroupTypes() []*models.GroupType {
+	return []*models.GroupType{
+		{
+			Name:        models.GroupTypeStudy,
+			MinCapacity: 2,
+			MaxCapacity: 50,
+			Creators:    models.CreatorsAnyone,
+			JoinPolicy:  models.JoinPolicyOpen,
+		},
+		{
+			Name:           models.GroupTypeTopicWeakness,
+			MinCapacity:    2,
+			MaxCapacity:    20,
+			DefaultPrivate: true,
+			Creators:       models.CreatorsAnyone,
+			JoinPolicy:     models.JoinPolicyApproval,
+			LifespanDays:   60,
+		},
+		{
+			Name:           models.GroupTypePairStudy,
+			MinCapacity:    2,
+			MaxCapacity:    2,
+			DefaultPrivate: true,
+			Creators:       models.CreatorsSystem,
+			JoinPolicy:     models.JoinPolicyInviteOnly,
+			LifespanDays:   90,
+		},
+	}
+}
+
+// GroupTypeRegistry looks group types up by name, ignoring case and spacing
+type GroupTypeRegistry struct {
+	types map[string]*models.GroupType
+}
+
+func NewGroupTypeRegistry(types ...*models.GroupType) *GroupTypeRegistry {
+	registry := &GroupTypeRegistry{
+		types: make(map[string]*models.GroupType),
+	}
+	for _, groupType := range types {
+		registry.Register(groupType)
+	}
+	return registry
+}
+
+func (r *GroupTypeRegistry) Register(groupType *models.GroupType) {
+	r.types[models.NormalizeTag(groupType.Name)] = groupType
+}
+
+// Lookup returns the named type, or nil if it is not registered
+func (r *GroupTypeRegistry) Lookup(name string) *models.GroupType {
+	return r.types[models.NormalizeTag(name)]
+}
+
+func (r *GroupTypeRegistry) All() []*models.GroupType {
+	types := make([]*models.GroupType, 0, len(r.types))
+	for _, groupType := range r.types {
+		types = append(types, groupType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	return types
+}
+
+func (s *GroupService) GroupTypes() []*models.GroupType {
+	return s.groupTypes.All()
+}
+
+// GroupType returns the named type, or nil if it is not registered
+func (s *GroupService) GroupType(name string) *models.GroupType {
+	return s.groupTypes.Lookup(name)
+}
+
+func validJoinPolicy(policy string) bool {
+	switch policy {
+	case models.JoinPolicyOpen, models.JoinPolicyApproval, models.JoinPolicyInviteOnly:
+		return true
+	}
+	return false
+}
+
+// applyGroupType checks a new group against the rules of its type and fills
+// in the type's defaults. Groups the platform makes itself, such as seeded
+// pair groups and breakouts, are stored directly and never come through here.
+func (s *GroupService) applyGroupType(group *models.Group) error {
+	groupType := s.groupTypes.Lookup(group.Type)
+	if groupType == nil {
+		return fmt.Errorf("unknown group type %q", group.Type)
+	}
+	group.Type = groupType.Name
+
+	switch groupType.Creators {
+	case models.CreatorsSystem:
+		return fmt.Errorf("%s groups can only be created by the system", groupType.Name)
+	case models.CreatorsAdmin:
+		if err := requireAdmin(s.store, group.CreateBy); err != nil {
+			return fmt.Errorf("%s groups can only be created by admins", groupType.Name)
+		}
+	}
+
+	if group.Capacity == 0 {
+		group.Capacity = groupType.MaxCapacity
+	}
+	if err := checkCapacity(groupType, group.Capacity); err != nil {
+		return err
+	}
+
+	if group.JoinPolicy == "" {
+		group.JoinPolicy = s.joinPolicy(group)
+	}
+	if err := checkJoinPolicy(groupType, group.JoinPolicy); err != nil {
+		return err
+	}
+
+	group.ExpiresAt = expiresAt(groupType, group.CreatedAt)
+	return nil
+}
+
+// expiresAt is when a group of the type created at the given time runs out,
+// or nil for types that last forever
+func expiresAt(groupType *models.GroupType, createdAt time.Time) *time.Time {
+	if groupType.LifespanDays <= 0 {
+		return nil
+	}
+	expiry := createdAt.AddDate(0, 0, groupType.LifespanDays)
+	return &expiry
+}
+
+func checkCapacity(groupType *models.GroupType, capacity int) error {
+	if capacity < groupType.MinCapacity || capacity > groupType.MaxCapacity {
+		if groupType.MinCapacity == groupType.MaxCapacity {
+			return fmt.Errorf("%s groups must have a capacity of %d", groupType.Name, groupType.MinCapacity)
+		}
+		return fmt.Errorf("%s groups must have a capacity between %d and %d", groupType.Name, groupType.MinCapacity, groupType.MaxCapacity)
+	}
+	return nil
+}
+
+func checkJoinPolicy(groupType *models.GroupType, policy string) error {
+	if !validJoinPolicy(policy) {
+		return fmt.Errorf("join policy must be %s, %s or %s", models.JoinPolicyOpen, models.JoinPolicyApproval, models.JoinPolicyInviteOnly)
+	}
+	if groupType.JoinPolicy == models.JoinPolicyInviteOnly && policy != models.JoinPolicyInviteOnly {
+		return fmt.Errorf("%s groups are invite only", groupType.Name)
+	}
+	return nil
+}
+
+// joinPolicy is the group's own policy, or else the one its type and privacy
+// imply: invite-only types stay invite only, and private groups need approval
+func (s *GroupService) joinPolicy(group *models.Group) string {
+	if group.JoinPolicy != "" {
+		return group.JoinPolicy
+	}
+	if groupType := s.groupTypes.Lookup(group.Type); groupType != nil {
+		if groupType.JoinPolicy == models.JoinPolicyInviteOnly || !group.Private {
+			return groupType.JoinPolicy
+		}
+	}
+	if group.Private {
+		return models.JoinPolicyApproval
+	}
+	return models.JoinPolicyOpen
+}
+
+// hasExpired reports whether the group has outlived its type's lifespan
+func hasExpired(group *models.Group, now time.Time) bool {
+	return group.ExpiresAt != nil && now.After(*group.ExpiresAt)
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalProdID    = "-//7cents//Study Sessions//EN"
+	icalLocalTime = "20060102T150405"
+	icalUTCTime   = "20060102T150405Z"
+)
+
+// calendarWriter builds an RFC 5545 calendar with CRLF line endings and
+// long lines folded at 75 octets. Events are kept aside until the end so the
+// time zones they use can be written ahead of them.
+type calendarWriter struct {
+	builder strings.Builder
+	events  strings.Builder
+	// timezones maps each TZID used to the year its first event starts in
+	timezones map[string]int
+	tzids     []string
+}
+
+func newCalendar(name string) *calendarWriter {
+	w := &calendarWriter{timezones: map[string]int{}}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icalProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + icalEscape(name))
+	return w
+}
+
+// addSession writes a session as an event, recurring ones with their RRULE.
+// Times carry the organiser's TZID so calendar apps handle clock changes.
+func (w *calendarWriter) addSession(session *models.StudySession, group *models.Group) {
+	loc, err := time.LoadLocation(session.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	tzid := loc.String()
+	start := session.Start.In(loc)
+	end := start.Add(time.Duration(session.DurationMinutes) * time.Minute)
+	if year, seen := w.timezones[tzid]; !seen {
+		w.tzids = append(w.tzids, tzid)
+		w.timezones[tzid] = start.Year()
+	} else if start.Year() < year {
+		w.timezones[tzid] = start.Year()
+	}
+
+	w.event("BEGIN:VEVENT")
+	w.event("UID:" + session.ID + "@7cents")
+	w.event("DTSTAMP:" + session.CreatedAt.UTC().Format(icalUTCTime))
+	w.event("DTSTART;TZID=" + tzid + ":" + start.Format(icalLocalTime))
+	w.event("DTEND;TZID=" + tzid + ":" + end.Format(icalLocalTime))
+	if session.RRule != "" {
+		w.event("RRULE:" + icalRRule(session.RRule, loc))
+	}
+	w.event("SUMMARY:" + icalEscape(session.Title))
+	if session.Description != "" {
+		w.event("DESCRIPTION:" + icalEscape(session.Description))
+	}
+	if group != nil {
+		w.event("CATEGORIES:" + icalEscape(group.Title))
+	}
+	w.event("END:VEVENT")
+}
+
+// icalRRule is the stored rule as it goes next to a DTSTART with a TZID,
+// where UNTIL has to be a UTC date-time. A date or local UNTIL becomes the
+// UTC instant of the last second it covers in the session's time zone.
+func icalRRule(rule string, loc *time.Location) string {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";")
+	for i, part := range parts {
+		name, value, _ := strings.Cut(part, "=")
+		if !strings.EqualFold(name, "UNTIL") {
+			continue
+		}
+		if until, err := parseRRuleUntil(value, loc); err == nil {
+			parts[i] = "UNTIL=" + until.UTC().Format(icalUTCTime)
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+func (w *calendarWriter) bytes() []byte {
+	for _, tzid := range w.tzids {
+		w.addTimezone(tzid, w.timezones[tzid])
+	}
+	w.builder.WriteString(w.events.String())
+	w.line("END:VCALENDAR")
+	return []byte(w.builder.String())
+}
+
+// addTimezone writes the VTIMEZONE that a TZID refers to. The clock changes
+// of the given year are repeated yearly by weekday, the way zone rules are
+// written, so later occurrences of recurring sessions keep their local time.
+func (w *calendarWriter) addTimezone(tzid string, year int) {
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + tzid)
+	transitions := zoneTransitions(loc, year)
+	if len(transitions) == 0 {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		w.observance("STANDARD", "19700101T000000", offset, offset, name, "")
+	}
+	for _, transition := range transitions {
+		_, from := transition.Add(-time.Second).Zone()
+		name, to := transition.Zone()
+		kind := "STANDARD"
+		if transition.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		// Observances start at the local time on the clock being changed
+		local := transition.In(time.FixedZone("", from))
+		w.observance(kind, local.Format(icalLocalTime), from, to, name, yearlyByDay(local))
+	}
+	w.line("END:VTIMEZONE")
+}
+
+func (w *calendarWriter) observance(kind string, start string, from int, to int, name string, rrule string) {
+	w.line("BEGIN:" + kind)
+	w.line("DTSTART:" + start)
+	if rrule != "" {
+		w.line("RRULE:" + rrule)
+	}
+	w.line("TZOFFSETFROM:" + icalOffset(from))
+	w.line("TZOFFSETTO:" + icalOffset(to))
+	w.line("TZNAME:" + name)
+	w.line("END:" + kind)
+}
+
+// zoneTransitions returns the instants the location's clocks change in a year
+func zoneTransitions(loc *time.Location, year int) []time.Time {
+	transitions := []time.Time{}
+	t := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || end.Year() > year {
+			return transitions
+		}
+		transitions = append(transitions, end)
+		t = end
+	}
+}
+
+// yearlyByDay is the yearly RRULE for a clock change on the same weekday of
+// the same week of the month, counting from the end for the last week
+func yearlyByDay(local time.Time) string {
+	week := (local.Day()-1)/7 + 1
+	if local.Day()+7 > daysIn(local.Month(), local.Year()) {
+		week = -1
+	}
+	weekday := strings.ToUpper(local.Weekday().String()[:2])
+	return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", local.Month(), week, weekday)
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+func (w *calendarWriter) line(content string) {
+	writeLine(&w.builder, content)
+}
+
+func (w *calendarWriter) event(content string) {
+	writeLine(&w.events, content)
+}
+
+func writeLine(builder *strings.Builder, content string) {
+	// Continuation lines start with a space, which counts towards their 75 octets
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		builder.WriteString(content[:cut])
+		builder.WriteString("\r\n ")
+		content = content[cut:]
+		limit = 74
+	}
+	builder.WriteString(content)
+	builder.WriteString("\r\n")
+}
+
+func icalEscape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
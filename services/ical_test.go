@@ -0,0 +1,65 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalRRuleUntilIsUTC(t *testing.T) {
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name string
+		rule string
+		loc  *time.Location
+		want string
+	}{
+		{"no until", "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4", kolkata, "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4"},
+		{"rrule prefix", "RRULE:FREQ=DAILY;COUNT=2", kolkata, "FREQ=DAILY;COUNT=2"},
+		{"date covers the whole local day", "FREQ=DAILY;UNTIL=20261022", kolkata, "FREQ=DAILY;UNTIL=20261022T182959Z"},
+		{"local date-time", "FREQ=DAILY;UNTIL=20261021T175959", kolkata, "FREQ=DAILY;UNTIL=20261021T122959Z"},
+		{"utc kept", "FREQ=DAILY;UNTIL=20261021T123000Z", kolkata, "FREQ=DAILY;UNTIL=20261021T123000Z"},
+		{"daylight saving offset", "FREQ=WEEKLY;until=20260701", newYork, "FREQ=WEEKLY;UNTIL=20260702T035959Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := icalRRule(tt.rule, tt.loc); got != tt.want {
+				t.Fatalf("icalRRule(%q) = %q, want %q", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarSessionEvent(t *testing.T) {
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+	calendar := newCalendar("Thermo")
+	calendar.addSession(&models.StudySession{
+		ID:              "s1",
+		Title:           "Revision, chapter 3",
+		Timezone:        "Asia/Kolkata",
+		Start:           time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+		DurationMinutes: 90,
+		RRule:           "FREQ=DAILY;UNTIL=20261022",
+		CreatedAt:       time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}, &models.Group{Title: "Thermo"})
+
+	ics := string(calendar.bytes())
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Kolkata\r\n",
+		"DTSTART;TZID=Asia/Kolkata:20261020T180000\r\n",
+		"DTEND;TZID=Asia/Kolkata:20261020T193000\r\n",
+		"RRULE:FREQ=DAILY;UNTIL=20261022T182959Z\r\n",
+		"SUMMARY:Revision\\, chapter 3\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Fatalf("calendar is missing %q:\n%s", want, ics)
+		}
+	}
+	if strings.Index(ics, "BEGIN:VTIMEZONE") > strings.Index(ics, "BEGIN:VEVENT") {
+		t.Fatalf("time zone is written after the event that uses it:\n%s", ics)
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InviteLinkBaseURL is the frontend route that redeems link invites
+const InviteLinkBaseURL = "http://localhost:3000/invite/"
+
+const (
+	defaultInviteExpiry = 7 * 24 * time.Hour
+	maxInviteExpiry     = 30 * 24 * time.Hour
+	inviteCodeLength    = 8
+	inviteTokenLength   = 22
+	// Ambiguous characters such as 0/O and 1/I are left out of shareable codes
+	inviteCodeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteTokenAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+func (s *GroupService) CreateInvite(groupID string, request *models.InviteCreateRequest) (*models.Invite, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, request.UserID) {
+		return nil, fmt.Errorf("only group members can create invites")
+	}
+
+	if request.MaxUses < 0 {
+		return nil, fmt.Errorf("max_uses cannot be negative")
+	}
+	if request.ExpiresInHours < 0 {
+		return nil, fmt.Errorf("expires_in_hours cannot be negative")
+	}
+	expiry := defaultInviteExpiry
+	if request.ExpiresInHours > 0 {
+		expiry = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+	if expiry > maxInviteExpiry {
+		return nil, fmt.Errorf("invites cannot be valid for more than %d hours", int(maxInviteExpiry.Hours()))
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(expiry)
+	invite := &models.Invite{
+		ID:         uuid.New().String(),
+		GroupID:    groupID,
+		Kind:       strings.ToUpper(request.Kind),
+		CreatedBy:  request.UserID,
+		CreatedAt:  now,
+		ExpiresAt:  &expiresAt,
+		MaxUses:    request.MaxUses,
+		RedeemedBy: []string{},
+	}
+
+	switch invite.Kind {
+	case models.InviteKindCode:
+		invite.Code, err = s.uniqueInviteCode(inviteCodeAlphabet, inviteCodeLength)
+	case models.InviteKindLink:
+		invite.Code, err = s.uniqueInviteCode(inviteTokenAlphabet, inviteTokenLength)
+		invite.Link = InviteLinkBaseURL + invite.Code
+	case models.InviteKindUser:
+		if request.InviteeID == "" {
+			return nil, fmt.Errorf("invitee_id is required for user invites")
+		}
+		if isMember(group, request.InviteeID) {
+			return nil, fmt.Errorf("user is already a member of this group")
+		}
+		invitee, err := s.store.GetUser(request.InviteeID)
+		if err != nil {
+			return nil, err
+		}
+		if invitee == nil {
+			return nil, fmt.Errorf("invitee not found")
+		}
+		invite.InviteeID = request.InviteeID
+		invite.MaxUses = 1
+		invite.Code, err = s.uniqueInviteCode(inviteCodeAlphabet, inviteCodeLength)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invite kind must be one of %s, %s or %s", models.InviteKindCode, models.InviteKindLink, models.InviteKindUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.CreateInvite(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// inviteKey pages invites newest first
+func inviteKey(invite *models.Invite) (int64, string) {
+	return invite.CreatedAt.UnixNano(), invite.ID
+}
+
+func (s *GroupService) GetGroupInvites(groupID string, userID string, page models.PageRequest) (*models.Page[*models.Invite], error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can view invites")
+	}
+
+	invites, err := s.store.GetInvitesByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(invites, "created", true, inviteKey, page)
+}
+
+func (s *GroupService) GetUserInvites(userID string, page models.PageRequest) (*models.Page[*models.Invite], error) {
+	invites, err := s.store.GetInvitesForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only show invites the user can still redeem
+	usable := []*models.Invite{}
+	for _, invite := range invites {
+		if inviteUnusableReason(invite, time.Now()) == "" {
+			usable = append(usable, invite)
+		}
+	}
+	return paginate(usable, "created", true, inviteKey, page)
+}
+
+func (s *GroupService) RevokeInvite(groupID string, inviteID string, userID string) error {
+	invite, err := s.store.GetInvite(inviteID)
+	if err != nil {
+		return err
+	}
+	if invite == nil || invite.GroupID != groupID {
+		return fmt.Errorf("invite not found")
+	}
+
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if invite.CreatedBy != userID && !canManageGroup(group, userID) {
+		return fmt.Errorf("only the invite creator, group owner or a moderator can revoke an invite")
+	}
+
+	invite.Revoked = true
+	return s.store.UpdateInvite(invite)
+}
+
+// RedeemInvite joins the user to the invite's group. Joining goes through
+// JoinGroup so the usual capacity and membership checks still apply.
+func (s *GroupService) RedeemInvite(code string, userID string) (*models.Invite, error) {
+	invite, err := s.store.GetInviteByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil {
+		// Short codes are handed out in upper case but may be typed in any case
+		invite, err = s.store.GetInviteByCode(strings.ToUpper(code))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if invite == nil {
+		return nil, fmt.Errorf("invite not found")
+	}
+
+	if reason := inviteUnusableReason(invite, time.Now()); reason != "" {
+		return nil, fmt.Errorf("%s", reason)
+	}
+	if invite.InviteeID != "" && invite.InviteeID != userID {
+		return nil, fmt.Errorf("this invite was issued to a different user")
+	}
+	for _, redeemedBy := range invite.RedeemedBy {
+		if redeemedBy == userID {
+			return nil, fmt.Errorf("invite has already been redeemed by this user")
+		}
+	}
+
+	if err := s.JoinGroup(invite.GroupID, userID); err != nil {
+		return nil, err
+	}
+
+	invite.Uses++
+	invite.RedeemedBy = append(invite.RedeemedBy, userID)
+	if err := s.store.UpdateInvite(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// inviteUnusableReason explains why an invite can no longer be redeemed, or
+// returns an empty string if it is still valid
+func inviteUnusableReason(invite *models.Invite, now time.Time) string {
+	if invite.Revoked {
+		return "invite has been revoked"
+	}
+	if invite.ExpiresAt != nil && now.After(*invite.ExpiresAt) {
+		return "invite has expired"
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return "invite has reached its use limit"
+	}
+	return ""
+}
+
+func (s *GroupService) uniqueInviteCode(alphabet string, length int) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := randomString(alphabet, length)
+		if err != nil {
+			return "", err
+		}
+		existing, err := s.store.GetInviteByCode(code)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate a unique invite code")
+}
+
+func randomString(alphabet string, length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return string(buf), nil
+}
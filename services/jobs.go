@@ -0,0 +1,41 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// storeLock serialises requests and background jobs. The memory store hands
+// out the models it keeps rather than copies, so anything that reads or
+// changes them holds it.
+var storeLock sync.Mutex
+
+func LockStore() {
+	storeLock.Lock()
+}
+
+func UnlockStore() {
+	storeLock.Unlock()
+}
+
+// Locked runs fn while holding the store lock
+func Locked(fn func() error) error {
+	storeLock.Lock()
+	defer storeLock.Unlock()
+	return fn()
+}
+
+// StartJob runs job every interval in the background, logging any errors.
+// Jobs hold the store lock while they run, like requests do.
+func StartJob(name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := Locked(job); err != nil {
+				log.Printf("job %s failed: %v", name, err)
+			}
+		}
+	}()
+}
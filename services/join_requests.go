@@ -0,0 +1,252 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestJoin joins the user straight away when the group is open, and
+// otherwise files a join request for the owner or a moderator to decide on.
+// Invite-only groups turn everyone away. Users the system recommended the
+// group to never need approval.
+func (s *GroupService) RequestJoin(groupID string, userID string) (*models.JoinResult, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Archived {
+		return nil, fmt.Errorf("group is archived")
+	}
+	if group.ParentID != "" {
+		return nil, fmt.Errorf("breakouts are set up from their main group")
+	}
+	if isBanned(group, userID) {
+		return nil, fmt.Errorf("user is banned from this group")
+	}
+	if hasExpired(group, time.Now()) {
+		return nil, fmt.Errorf("group has expired")
+	}
+	if isMember(group, userID) {
+		return nil, fmt.Errorf("user is already a member of this group")
+	}
+
+	recommended, err := s.isRecommended(group.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	policy := s.joinPolicy(group)
+	if policy == models.JoinPolicyInviteOnly && !recommended {
+		return nil, fmt.Errorf("this group can only be joined with an invite")
+	}
+
+	if policy != models.JoinPolicyApproval || recommended {
+		if isFull(group) {
+			position, err := s.joinWaitlist(groupID, userID)
+			if err != nil {
+				return nil, err
+			}
+			return &models.JoinResult{Status: models.JoinStatusWaitlisted, Waitlist: position}, nil
+		}
+		if err := s.JoinGroup(groupID, userID); err != nil {
+			return nil, err
+		}
+		return &models.JoinResult{Status: models.JoinStatusJoined}, nil
+	}
+
+	// Asking twice returns the request that is already waiting
+	requests, err := s.store.GetJoinRequestsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		if request.UserID == userID && request.Status == models.JoinRequestPending {
+			return &models.JoinResult{Status: models.JoinStatusPending, Request: request}, nil
+		}
+	}
+
+	request := &models.JoinRequest{
+		ID:        uuid.New().String(),
+		GroupID:   groupID,
+		UserID:    userID,
+		Status:    models.JoinRequestPending,
+		CreatedAt: time.Now(),
+	}
+	if err := s.store.CreateJoinRequest(request); err != nil {
+		return nil, err
+	}
+	return &models.JoinResult{Status: models.JoinStatusPending, Request: request}, nil
+}
+
+// isRecommended reports whether the system recommended the group to the user
+func (s *GroupService) isRecommended(groupID string, userID string) (bool, error) {
+	userGroup, err := s.store.GetUserGroup(userID)
+	if err != nil {
+		return false, err
+	}
+	if userGroup != nil {
+		for _, recGroupID := range userGroup.RecommendedGroups {
+			if recGroupID == groupID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// joinRequestKey pages join requests oldest first
+func joinRequestKey(request *models.JoinRequest) (int64, string) {
+	return request.CreatedAt.UnixNano(), request.ID
+}
+
+func (s *GroupService) GetJoinRequests(groupID string, actorID string, status string, page models.PageRequest) (*models.Page[*models.JoinRequest], error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can view join requests")
+	}
+
+	requests, err := s.store.GetJoinRequestsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []*models.JoinRequest{}
+	for _, request := range requests {
+		if status == "" || request.Status == status {
+			filtered = append(filtered, request)
+		}
+	}
+	return paginate(filtered, "created", false, joinRequestKey, page)
+}
+
+func (s *GroupService) GetUserJoinRequests(userID string, page models.PageRequest) (*models.Page[*models.JoinRequest], error) {
+	requests, err := s.store.GetJoinRequestsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(requests, "created", false, joinRequestKey, page)
+}
+
+// ApproveJoinRequest adds the requester to the group, or to its waitlist if
+// the group is full. The request stays pending if the join itself fails,
+// except when the requester has become a member some other way meanwhile.
+func (s *GroupService) ApproveJoinRequest(groupID string, requestID string, actorID string) (*models.JoinRequest, error) {
+	request, err := s.pendingJoinRequest(groupID, requestID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case isMember(group, request.UserID):
+		// Already in through an invite or the waitlist, so only the
+		// request needs settling
+	case isFull(group):
+		if _, err := s.joinWaitlist(groupID, request.UserID); err != nil {
+			return nil, err
+		}
+	default:
+		if err := s.JoinGroup(groupID, request.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return request, s.decideJoinRequest(request, models.JoinRequestApproved, actorID)
+}
+
+func (s *GroupService) RejectJoinRequest(groupID string, requestID string, actorID string) (*models.JoinRequest, error) {
+	request, err := s.pendingJoinRequest(groupID, requestID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return request, s.decideJoinRequest(request, models.JoinRequestRejected, actorID)
+}
+
+func (s *GroupService) pendingJoinRequest(groupID string, requestID string, actorID string) (*models.JoinRequest, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can decide on join requests")
+	}
+
+	request, err := s.store.GetJoinRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || request.GroupID != groupID {
+		return nil, fmt.Errorf("join request not found")
+	}
+	if request.Status != models.JoinRequestPending {
+		return nil, fmt.Errorf("join request has already been %s", request.Status)
+	}
+	return request, nil
+}
+
+func (s *GroupService) decideJoinRequest(request *models.JoinRequest, status string, actorID string) error {
+	now := time.Now()
+	request.Status = status
+	request.DecidedBy = actorID
+	request.DecidedAt = &now
+	return s.store.UpdateJoinRequest(request)
+}
+
+// AddModerator lets the owner give a member moderation rights
+func (s *GroupService) AddModerator(groupID string, userID string, actorID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if group.CreateBy != actorID {
+		return fmt.Errorf("only the group owner can appoint moderators")
+	}
+	if !isMember(group, userID) {
+		return fmt.Errorf("user is not a member of this group")
+	}
+	if group.CreateBy == userID || isModerator(group, userID) {
+		return fmt.Errorf("user can already moderate this group")
+	}
+
+	group.Moderators = append(group.Moderators, userID)
+	return s.store.UpdateGroup(group)
+}
+
+func (s *GroupService) RemoveModerator(groupID string, userID string, actorID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if group.CreateBy != actorID {
+		return fmt.Errorf("only the group owner can remove moderators")
+	}
+	if !isModerator(group, userID) {
+		return fmt.Errorf("user is not a moderator of this group")
+	}
+
+	group.Moderators = removeID(group.Moderators, userID)
+	return s.store.UpdateGroup(group)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"testing"
+)
+
+func TestApproveJoinRequestFromAMemberSettlesIt(t *testing.T) {
+	store := storage.NewMemoryStore()
+	groups := NewGroupService(store)
+
+	group := &models.Group{Title: "Thermo", Type: models.GroupTypeStudy, CreateBy: "1", Tag: "Physics", Capacity: 10, Private: true, JoinPolicy: models.JoinPolicyApproval}
+	if err := groups.CreateGroup(group); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	request := &models.JoinRequest{ID: "r1", GroupID: group.ID, UserID: "2", Status: models.JoinRequestPending}
+	if err := store.CreateJoinRequest(request); err != nil {
+		t.Fatalf("CreateJoinRequest: %v", err)
+	}
+	// The requester got in another way, such as an invite, before anyone decided
+	if err := store.AddMemberToGroup(group.ID, "2"); err != nil {
+		t.Fatalf("AddMemberToGroup: %v", err)
+	}
+
+	approved, err := groups.ApproveJoinRequest(group.ID, request.ID, "1")
+	if err != nil {
+		t.Fatalf("ApproveJoinRequest: %v", err)
+	}
+	if approved.Status != models.JoinRequestApproved || approved.DecidedBy != "1" {
+		t.Fatalf("request = %+v, want it approved by 1", approved)
+	}
+}
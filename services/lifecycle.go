@@ -0,0 +1,262 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmptyGroupPolicy decides what happens to groups nobody is a member of.
+// A zero duration switches that step off.
+type EmptyGroupPolicy struct {
+	ArchiveAfter time.Duration
+	DeleteAfter  time.Duration
+}
+
+var DefaultEmptyGroupPolicy = EmptyGroupPolicy{
+	ArchiveAfter: 7 * 24 * time.Hour,
+	DeleteAfter:  0,
+}
+
+// GetEmptyGroupPolicy returns the empty-group policy in hours
+func (s *GroupService) GetEmptyGroupPolicy() models.EmptyGroupSettings {
+	return models.EmptyGroupSettings{
+		ArchiveAfterHours: s.emptyGroupPolicy.ArchiveAfter.Hours(),
+		DeleteAfterHours:  s.emptyGroupPolicy.DeleteAfter.Hours(),
+	}
+}
+
+// SetEmptyGroupPolicy changes how long empty groups are kept (admin only).
+// The next empty-groups run applies it.
+func (s *GroupService) SetEmptyGroupPolicy(actorID string, settings models.EmptyGroupSettings) error {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return err
+	}
+	if settings.ArchiveAfterHours < 0 || settings.DeleteAfterHours < 0 {
+		return fmt.Errorf("empty-group periods cannot be negative")
+	}
+	s.emptyGroupPolicy = EmptyGroupPolicy{
+		ArchiveAfter: hoursToDuration(settings.ArchiveAfterHours),
+		DeleteAfter:  hoursToDuration(settings.DeleteAfterHours),
+	}
+	return nil
+}
+
+func hoursToDuration(hours float64) time.Duration {
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// TransferOwnership hands the group over to another member
+func (s *GroupService) TransferOwnership(groupID string, newOwnerID string, actorID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	if group.CreateBy != actorID {
+		return fmt.Errorf("only the group owner can transfer ownership")
+	}
+	if newOwnerID == actorID {
+		return fmt.Errorf("user already owns this group")
+	}
+	if !isMember(group, newOwnerID) {
+		return fmt.Errorf("new owner must be a member of this group")
+	}
+
+	return s.setOwner(group, newOwnerID, fmt.Sprintf("%s handed ownership of the group to %s", actorID, newOwnerID))
+}
+
+// handOverOwnership passes the group to its longest-standing member when the
+// owner leaves. Members are kept in join order, so that is the first one.
+func (s *GroupService) handOverOwnership(group *models.Group, formerOwnerID string) error {
+	if group.CreateBy != formerOwnerID || len(group.Members) == 0 {
+		return nil
+	}
+	newOwnerID := group.Members[0]
+	return s.setOwner(group, newOwnerID, fmt.Sprintf("%s left the group, ownership passed to %s", formerOwnerID, newOwnerID))
+}
+
+func (s *GroupService) setOwner(group *models.Group, ownerID string, reason string) error {
+	group.CreateBy = ownerID
+	// The owner already has every moderator right
+	group.Moderators = removeID(group.Moderators, ownerID)
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+
+	message := models.Message{
+		ID:        uuid.New().String(),
+		Content:   reason,
+		SenderId:  "system",
+		Timestamp: time.Now(),
+	}
+	return s.addMessage(group.ID, &message)
+}
+
+// ArchiveExpiredGroups archives groups that have outlived their type's lifespan
+func (s *GroupService) ArchiveExpiredGroups() error {
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		if group.Archived || !hasExpired(group, now) {
+			continue
+		}
+		if err := s.archiveGroup(group, "system", now, "The meeting ended because the group expired"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveGroup archives a group, first ending any meeting still running in
+// it with the given reason
+func (s *GroupService) archiveGroup(group *models.Group, endedBy string, now time.Time, reason string) error {
+	meeting, err := s.store.GetOpenMeeting(group.ID)
+	if err != nil {
+		return err
+	}
+	if meeting != nil {
+		if err := s.closeMeeting(group, meeting, endedBy, now, reason); err != nil {
+			return err
+		}
+	}
+	group.Archived = true
+	group.ArchivedAt = &now
+	return s.store.UpdateGroup(group)
+}
+
+// ApplyEmptyGroupPolicy archives and eventually deletes groups that have had
+// no members for longer than the configured periods
+func (s *GroupService) ApplyEmptyGroupPolicy() error {
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		if len(group.Members) > 0 {
+			continue
+		}
+
+		// The clock starts when the last member leaves, so groups that have
+		// never had members, like the seeded recommendations, are left alone
+		if group.EmptySince == nil {
+			continue
+		}
+
+		emptyFor := now.Sub(*group.EmptySince)
+		if s.emptyGroupPolicy.DeleteAfter > 0 && emptyFor >= s.emptyGroupPolicy.DeleteAfter {
+			if err := s.closeOutGroup(group.ID, "system", now); err != nil {
+				return err
+			}
+			if err := s.dropGroupReferences(group.ID); err != nil {
+				return err
+			}
+			if err := s.store.DeleteGroup(group.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if s.emptyGroupPolicy.ArchiveAfter > 0 && emptyFor >= s.emptyGroupPolicy.ArchiveAfter && !group.Archived {
+			if err := s.archiveGroup(group, "system", now, "The meeting ended because the group was archived"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// closeOutGroup ends everything still waiting on a group that is going away.
+// Waitlist places expire, unused invites are revoked, pending join requests
+// are rejected and scheduled sessions are cancelled.
+func (s *GroupService) closeOutGroup(groupID string, actorID string, now time.Time) error {
+	entries, err := s.store.GetWaitlistByGroup(groupID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistAdmitted {
+			continue
+		}
+		entry.Status = models.WaitlistExpired
+		if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+			return err
+		}
+	}
+
+	invites, err := s.store.GetInvitesByGroup(groupID)
+	if err != nil {
+		return err
+	}
+	for _, invite := range invites {
+		if invite.Revoked {
+			continue
+		}
+		invite.Revoked = true
+		if err := s.store.UpdateInvite(invite); err != nil {
+			return err
+		}
+	}
+
+	requests, err := s.store.GetJoinRequestsByGroup(groupID)
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		if request.Status != models.JoinRequestPending {
+			continue
+		}
+		request.Status = models.JoinRequestRejected
+		request.DecidedBy = actorID
+		request.DecidedAt = &now
+		if err := s.store.UpdateJoinRequest(request); err != nil {
+			return err
+		}
+	}
+
+	sessions, err := s.store.GetSessionsByGroup(groupID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := s.store.DeleteSession(session.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropGroupReferences takes a deleted group off every user's active and
+// recommended lists
+func (s *GroupService) dropGroupReferences(groupID string) error {
+	userGroups, err := s.store.GetAllUserGroups()
+	if err != nil {
+		return err
+	}
+	for _, userGroup := range userGroups {
+		activeGroups := removeID(userGroup.ActiveGroups, groupID)
+		recommendedGroups := removeID(userGroup.RecommendedGroups, groupID)
+		recommendationsChanged := len(recommendedGroups) != len(userGroup.RecommendedGroups)
+		if len(activeGroups) == len(userGroup.ActiveGroups) && !recommendationsChanged {
+			continue
+		}
+		userGroup.ActiveGroups = activeGroups
+		userGroup.RecommendedGroups = recommendedGroups
+		if err := s.store.UpdateUserGroup(userGroup); err != nil {
+			return err
+		}
+		if recommendationsChanged {
+			s.recommendationsChanged(userGroup)
+		}
+	}
+	return nil
+}
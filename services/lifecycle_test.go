@@ -0,0 +1,99 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"testing"
+	"time"
+)
+
+func TestEmptyGroupPolicyLeavesGroupsThatNeverHadMembers(t *testing.T) {
+	store := storage.NewMemoryStore()
+	groups := NewGroupService(store)
+	groups.emptyGroupPolicy = EmptyGroupPolicy{ArchiveAfter: time.Nanosecond, DeleteAfter: time.Nanosecond}
+
+	seeded, err := store.GetAllGroups()
+	if err != nil {
+		t.Fatalf("GetAllGroups: %v", err)
+	}
+	if err := groups.ApplyEmptyGroupPolicy(); err != nil {
+		t.Fatalf("ApplyEmptyGroupPolicy: %v", err)
+	}
+	for _, group := range seeded {
+		stored, err := store.GetGroup(group.ID)
+		if err != nil {
+			t.Fatalf("GetGroup: %v", err)
+		}
+		if stored == nil || stored.Archived || stored.EmptySince != nil {
+			t.Fatalf("group %q was touched by the empty-group policy: %+v", group.Title, stored)
+		}
+	}
+}
+
+func TestEmptyGroupPolicyDeleteCleansUp(t *testing.T) {
+	store := storage.NewMemoryStore()
+	groups := NewGroupService(store)
+	groups.emptyGroupPolicy = EmptyGroupPolicy{DeleteAfter: time.Hour}
+
+	group := &models.Group{Title: "Thermo", Type: models.GroupTypeStudy, CreateBy: "1", Tag: "Physics", Capacity: 10}
+	if err := groups.CreateGroup(group); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	request := &models.JoinRequest{ID: "r1", GroupID: group.ID, UserID: "2", Status: models.JoinRequestPending}
+	invite := &models.Invite{ID: "i1", GroupID: group.ID, Kind: models.InviteKindCode, Code: "THERMO"}
+	for _, err := range []error{store.CreateJoinRequest(request), store.CreateInvite(invite)} {
+		if err != nil {
+			t.Fatalf("seeding: %v", err)
+		}
+	}
+	userGroup, err := store.GetUserGroup("3")
+	if err != nil || userGroup == nil {
+		t.Fatalf("GetUserGroup: %v, %v", userGroup, err)
+	}
+	userGroup.RecommendedGroups = append(userGroup.RecommendedGroups, group.ID)
+	if err := store.UpdateUserGroup(userGroup); err != nil {
+		t.Fatalf("UpdateUserGroup: %v", err)
+	}
+
+	if err := groups.LeaveGroup(group.ID, "1"); err != nil {
+		t.Fatalf("LeaveGroup: %v", err)
+	}
+	emptied, err := store.GetGroup(group.ID)
+	if err != nil || emptied == nil || emptied.EmptySince == nil {
+		t.Fatalf("the empty-group clock did not start when the last member left: %+v, %v", emptied, err)
+	}
+	// Joined the waitlist after the seat was freed, so nobody was admitted
+	entry := &models.WaitlistEntry{ID: "w1", GroupID: group.ID, UserID: "4", Status: models.WaitlistWaiting}
+	if err := store.CreateWaitlistEntry(entry); err != nil {
+		t.Fatalf("CreateWaitlistEntry: %v", err)
+	}
+	longAgo := time.Now().Add(-2 * time.Hour)
+	emptied.EmptySince = &longAgo
+	if err := groups.ApplyEmptyGroupPolicy(); err != nil {
+		t.Fatalf("ApplyEmptyGroupPolicy: %v", err)
+	}
+
+	if deleted, _ := store.GetGroup(group.ID); deleted != nil {
+		t.Fatalf("group was not deleted")
+	}
+	if request.Status != models.JoinRequestRejected {
+		t.Fatalf("join request status = %s, want %s", request.Status, models.JoinRequestRejected)
+	}
+	if !invite.Revoked {
+		t.Fatalf("invite was not revoked")
+	}
+	if entry.Status != models.WaitlistExpired {
+		t.Fatalf("waitlist status = %s, want %s", entry.Status, models.WaitlistExpired)
+	}
+	for _, userID := range []string{"1", "3"} {
+		userGroup, err := store.GetUserGroup(userID)
+		if err != nil {
+			t.Fatalf("GetUserGroup: %v", err)
+		}
+		for _, groupID := range append(userGroup.ActiveGroups, userGroup.RecommendedGroups...) {
+			if groupID == group.ID {
+				t.Fatalf("user %s still lists the deleted group", userID)
+			}
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StartMeeting opens a meeting for the group with the user as host and first
+// attendee. It can be tied to a scheduled session of the group.
+func (s *GroupService) StartMeeting(groupID string, hostID string, sessionID string) (*models.Meeting, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Archived {
+		return nil, fmt.Errorf("group is archived")
+	}
+	if !isMember(group, hostID) {
+		return nil, fmt.Errorf("only group members can start a meeting")
+	}
+	open, err := s.store.GetOpenMeeting(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, fmt.Errorf("a meeting is already in progress")
+	}
+	if sessionID != "" {
+		session, err := s.store.GetSession(sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.GroupID != groupID {
+			return nil, fmt.Errorf("session not found")
+		}
+	}
+
+	now := time.Now()
+	meeting := &models.Meeting{
+		ID:        uuid.New().String(),
+		GroupID:   groupID,
+		SessionID: sessionID,
+		HostID:    hostID,
+		StartedAt: now,
+		Attendance: []models.MeetingAttendance{
+			{UserID: hostID, JoinedAt: now},
+		},
+	}
+	summariseAttendance(meeting, now)
+	if err := s.store.CreateMeeting(meeting); err != nil {
+		return nil, err
+	}
+	if err := s.setMeetingStarted(group, true, fmt.Sprintf("%s started a meeting", hostID)); err != nil {
+		return nil, err
+	}
+	s.publish(groupID, models.EventMeetingStarted, *meeting)
+	if err := s.refreshActivityScore(groupID); err != nil {
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// JoinMeeting adds a member to the group's open meeting
+func (s *GroupService) JoinMeeting(groupID string, userID string) (*models.Meeting, error) {
+	group, meeting, err := s.openMeeting(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can join the meeting")
+	}
+	if isPresent(meeting, userID) {
+		return nil, fmt.Errorf("user is already in the meeting")
+	}
+
+	now := time.Now()
+	meeting.Attendance = append(meeting.Attendance, models.MeetingAttendance{UserID: userID, JoinedAt: now})
+	summariseAttendance(meeting, now)
+	if err := s.store.UpdateMeeting(meeting); err != nil {
+		return nil, err
+	}
+	s.publish(groupID, models.EventMeetingUpdated, *meeting)
+	if err := s.refreshActivityScore(groupID); err != nil {
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// LeaveMeeting takes a user out of the group's open meeting. The meeting ends
+// once the last attendee has left.
+func (s *GroupService) LeaveMeeting(groupID string, userID string) (*models.Meeting, error) {
+	group, meeting, err := s.openMeeting(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPresent(meeting, userID) {
+		return nil, fmt.Errorf("user is not in the meeting")
+	}
+
+	now := time.Now()
+	closeAttendance(meeting, userID, now)
+	for _, attendance := range meeting.Attendance {
+		if attendance.LeftAt == nil {
+			summariseAttendance(meeting, now)
+			if err := s.store.UpdateMeeting(meeting); err != nil {
+				return nil, err
+			}
+			s.publish(groupID, models.EventMeetingUpdated, *meeting)
+			return meeting, nil
+		}
+	}
+
+	if err := s.closeMeeting(group, meeting, userID, now, "The meeting ended after everyone left"); err != nil {
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// EndMeeting closes the group's open meeting for everyone. The host, the
+// owner and moderators can end it.
+func (s *GroupService) EndMeeting(groupID string, actorID string) (*models.Meeting, error) {
+	group, meeting, err := s.openMeeting(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting.HostID != actorID && !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the host, the group owner or a moderator can end the meeting")
+	}
+
+	if err := s.closeMeeting(group, meeting, actorID, time.Now(), fmt.Sprintf("%s ended the meeting", actorID)); err != nil {
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// GetCurrentMeeting returns the group's open meeting, or nil if there is none
+func (s *GroupService) GetCurrentMeeting(groupID string) (*models.Meeting, error) {
+	meeting, err := s.store.GetOpenMeeting(groupID)
+	if err != nil || meeting == nil {
+		return nil, err
+	}
+	summariseAttendance(meeting, time.Now())
+	return meeting, nil
+}
+
+// GetMeetings lists a group's meetings, most recent first
+func (s *GroupService) GetMeetings(groupID string, userID string, page models.PageRequest) (*models.Page[*models.Meeting], error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Private && !isMember(group, userID) {
+		return nil, fmt.Errorf("only members can see the meetings of a private group")
+	}
+
+	meetings, err := s.store.GetMeetingsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if meetings == nil {
+		meetings = []*models.Meeting{}
+	}
+	now := time.Now()
+	for _, meeting := range meetings {
+		if meeting.EndedAt == nil {
+			summariseAttendance(meeting, now)
+		}
+	}
+	return paginate(meetings, "started", true, func(meeting *models.Meeting) (int64, string) {
+		return meeting.StartedAt.UnixNano(), meeting.ID
+	}, page)
+}
+
+// openMeeting loads a group together with its open meeting
+func (s *GroupService) openMeeting(groupID string) (*models.Group, *models.Meeting, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group == nil {
+		return nil, nil, fmt.Errorf("group not found")
+	}
+	meeting, err := s.store.GetOpenMeeting(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meeting == nil {
+		return nil, nil, fmt.Errorf("no meeting is in progress")
+	}
+	return group, meeting, nil
+}
+
+func (s *GroupService) closeMeeting(group *models.Group, meeting *models.Meeting, endedBy string, now time.Time, reason string) error {
+	for i := range meeting.Attendance {
+		if meeting.Attendance[i].LeftAt == nil {
+			meeting.Attendance[i].LeftAt = &now
+		}
+	}
+	meeting.EndedAt = &now
+	meeting.EndedBy = endedBy
+	summariseAttendance(meeting, now)
+	if err := s.store.UpdateMeeting(meeting); err != nil {
+		return err
+	}
+	if err := s.recordAttendance(meeting); err != nil {
+		return err
+	}
+	if err := s.setMeetingStarted(group, false, reason); err != nil {
+		return err
+	}
+	s.publish(group.ID, models.EventMeetingEnded, *meeting)
+	return nil
+}
+
+// setMeetingStarted keeps Group.MeetingStarted in step with the open meeting
+// and tells the group about the change
+func (s *GroupService) setMeetingStarted(group *models.Group, started bool, reason string) error {
+	group.MeetingStarted = started
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+
+	message := models.Message{
+		ID:        uuid.New().String(),
+		Content:   reason,
+		SenderId:  "system",
+		Timestamp: time.Now(),
+	}
+	return s.addMessage(group.ID, &message)
+}
+
+// leaveMeetingIfPresent drops a user who leaves the group from its meeting
+func (s *GroupService) leaveMeetingIfPresent(groupID string, userID string) error {
+	meeting, err := s.store.GetOpenMeeting(groupID)
+	if err != nil || meeting == nil || !isPresent(meeting, userID) {
+		return err
+	}
+	_, err = s.LeaveMeeting(groupID, userID)
+	return err
+}
+
+func isPresent(meeting *models.Meeting, userID string) bool {
+	for _, attendance := range meeting.Attendance {
+		if attendance.UserID == userID && attendance.LeftAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func closeAttendance(meeting *models.Meeting, userID string, now time.Time) {
+	for i := range meeting.Attendance {
+		if meeting.Attendance[i].UserID == userID && meeting.Attendance[i].LeftAt == nil {
+			meeting.Attendance[i].LeftAt = &now
+		}
+	}
+}
+
+// summariseAttendance rebuilds the per-user totals from the attendance
+// stretches, in the order users first joined
+func summariseAttendance(meeting *models.Meeting, now time.Time) {
+	attendees := []models.MeetingAttendee{}
+	index := make(map[string]int)
+	for _, attendance := range meeting.Attendance {
+		i, exists := index[attendance.UserID]
+		if !exists {
+			i = len(attendees)
+			index[attendance.UserID] = i
+			attendees = append(attendees, models.MeetingAttendee{
+				UserID:        attendance.UserID,
+				FirstJoinedAt: attendance.JoinedAt,
+			})
+		}
+		leftAt := now
+		if attendance.LeftAt != nil {
+			leftAt = *attendance.LeftAt
+		} else {
+			attendees[i].Present = true
+		}
+		attendees[i].DurationSeconds += int64(leftAt.Sub(attendance.JoinedAt).Seconds())
+	}
+	meeting.Attendees = attendees
+}
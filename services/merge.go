@@ -0,0 +1,375 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Groups at most this full and at most this active are suggested for merging
+const (
+	mergeMaxFill     = 0.5
+	mergeMaxActivity = 20
+)
+
+// MergeGroups folds the source groups into the target. Members move over
+// until the target is full and the rest join its waitlist, message and
+// action histories are interleaved with the group they came from, and the
+// sources are archived and point at the target so their IDs still resolve.
+// People on a source's waitlist queue for the target instead, and the
+// sources' invites, pending join requests and sessions are cancelled.
+func (s *GroupService) MergeGroups(request *models.GroupMergeRequest) (*models.GroupMergeResult, error) {
+	if err := requireAdmin(s.store, request.ActorID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.mergeableGroup(request.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	sourceIDs := uniqueSorted(request.SourceIDs)
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("at least one group to merge is required")
+	}
+	sources := make([]*models.Group, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID == target.ID {
+			return nil, fmt.Errorf("a group cannot be merged into itself")
+		}
+		source, err := s.mergeableGroup(sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if source.Type != target.Type {
+			return nil, fmt.Errorf("group %s is a %s group and cannot be merged into a %s group", source.ID, source.Type, target.Type)
+		}
+		sources = append(sources, source)
+	}
+
+	result := &models.GroupMergeResult{Merged: sourceIDs, Moved: []string{}, Waitlisted: []string{}, Banned: []string{}}
+	for _, source := range sources {
+		for _, memberID := range source.Members {
+			if err := s.recordMembership(source.ID, memberID, models.MembershipLeft); err != nil {
+				return nil, err
+			}
+			if isMember(target, memberID) {
+				continue
+			}
+			// Bans on the target still apply to people arriving by merge
+			if isBanned(target, memberID) {
+				result.Banned = append(result.Banned, memberID)
+				continue
+			}
+			if isFull(target) {
+				if _, err := s.joinWaitlist(target.ID, memberID); err != nil {
+					return nil, err
+				}
+				result.Waitlisted = append(result.Waitlisted, memberID)
+				continue
+			}
+			if err := s.store.AddMemberToGroup(target.ID, memberID); err != nil {
+				return nil, err
+			}
+			if err := s.recordMembership(target.ID, memberID, models.MembershipJoined); err != nil {
+				return nil, err
+			}
+			result.Moved = append(result.Moved, memberID)
+
+			// Whoever ran a merged group keeps moderating it
+			if (source.CreateBy == memberID || isModerator(source, memberID)) && !canManageGroup(target, memberID) {
+				target.Moderators = append(target.Moderators, memberID)
+			}
+		}
+	}
+
+	// Interleave the histories, labelling everything that came from a source
+	messages := target.Messages
+	actions := target.Actions
+	for _, source := range sources {
+		for _, message := range source.Messages {
+			if message.FromGroupID == "" {
+				message.FromGroupID = source.ID
+				message.FromGroup = source.Title
+			}
+			messages = append(messages, message)
+		}
+		for _, action := range source.Actions {
+			if action.FromGroupID == "" {
+				action.FromGroupID = source.ID
+				action.FromGroup = source.Title
+			}
+			actions = append(actions, action)
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Timestamp.Before(actions[j].Timestamp)
+	})
+	target.Messages = messages
+	target.Actions = actions
+	target.EmptySince = nil
+	if err := s.store.UpdateGroup(target); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	titles := make([]string, 0, len(sources))
+	for _, source := range sources {
+		titles = append(titles, source.Title)
+		source.Members = []string{}
+		source.Moderators = nil
+		source.Messages = nil
+		source.Actions = nil
+		source.MergedInto = target.ID
+		// Sources with a meeting in progress are refused above
+		if err := s.archiveGroup(source, request.ActorID, now, "The meeting ended because the group was merged"); err != nil {
+			return nil, err
+		}
+		if err := s.moveWaitlist(source.ID, target); err != nil {
+			return nil, err
+		}
+		if err := s.closeOutGroup(source.ID, request.ActorID, now); err != nil {
+			return nil, err
+		}
+	}
+	// Seats the merge left free go to whoever is waiting
+	if err := s.admitFromWaitlist(target.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.redirectUserGroups(sourceIDs, target); err != nil {
+		return nil, err
+	}
+	if err := s.postSystemMessage(target.ID, fmt.Sprintf("%s merged into this group", strings.Join(titles, ", "))); err != nil {
+		return nil, err
+	}
+	if err := s.refreshActivityScore(target.ID); err != nil {
+		return nil, err
+	}
+
+	result.Group = target
+	return result, nil
+}
+
+// SuggestGroupMerges groups quiet, half-empty public groups by tag and type
+// and packs each set into as few groups as their capacities allow. The
+// fullest group of each pack is the suggested target.
+func (s *GroupService) SuggestGroupMerges(actorID string) ([]*models.GroupMergeSuggestion, error) {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return nil, err
+	}
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := map[string][]*models.Group{}
+	for _, group := range groups {
+		if group.Private || group.Archived || len(group.Members) == 0 {
+			continue
+		}
+		if float64(len(group.Members)) > mergeMaxFill*float64(group.Capacity) || group.ActivityScore > mergeMaxActivity {
+			continue
+		}
+		key := group.Tag + "\x00" + group.Type
+		buckets[key] = append(buckets[key], group)
+	}
+
+	suggestions := []*models.GroupMergeSuggestion{}
+	for _, bucket := range buckets {
+		sort.Slice(bucket, func(i, j int) bool {
+			if len(bucket[i].Members) != len(bucket[j].Members) {
+				return len(bucket[i].Members) > len(bucket[j].Members)
+			}
+			if bucket[i].ActivityScore != bucket[j].ActivityScore {
+				return bucket[i].ActivityScore > bucket[j].ActivityScore
+			}
+			return bucket[i].ID < bucket[j].ID
+		})
+
+		// First fit: each group joins the first pack it fits in whole
+		type pack struct {
+			target  *models.Group
+			sources []string
+			members map[string]bool
+		}
+		var packs []*pack
+		for _, group := range bucket {
+			placed := false
+			for _, p := range packs {
+				combined := len(p.members)
+				for _, memberID := range group.Members {
+					if !p.members[memberID] {
+						combined++
+					}
+				}
+				if combined > p.target.Capacity {
+					continue
+				}
+				p.sources = append(p.sources, group.ID)
+				for _, memberID := range group.Members {
+					p.members[memberID] = true
+				}
+				placed = true
+				break
+			}
+			if !placed {
+				p := &pack{target: group, members: map[string]bool{}}
+				for _, memberID := range group.Members {
+					p.members[memberID] = true
+				}
+				packs = append(packs, p)
+			}
+		}
+
+		for _, p := range packs {
+			if len(p.sources) == 0 {
+				continue
+			}
+			suggestions = append(suggestions, &models.GroupMergeSuggestion{
+				TargetID:  p.target.ID,
+				SourceIDs: p.sources,
+				Tag:       p.target.Tag,
+				Type:      p.target.Type,
+				Members:   len(p.members),
+				Capacity:  p.target.Capacity,
+			})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Tag != suggestions[j].Tag {
+			return suggestions[i].Tag < suggestions[j].Tag
+		}
+		return suggestions[i].TargetID < suggestions[j].TargetID
+	})
+	return suggestions, nil
+}
+
+// MergedGroupID follows merge redirects to the group that now holds the
+// given group's members. It returns the ID unchanged for unmerged groups.
+func (s *GroupService) MergedGroupID(groupID string) (string, error) {
+	seen := map[string]bool{}
+	for !seen[groupID] {
+		seen[groupID] = true
+		group, err := s.store.GetGroup(groupID)
+		if err != nil {
+			return "", err
+		}
+		if group == nil || group.MergedInto == "" {
+			return groupID, nil
+		}
+		groupID = group.MergedInto
+	}
+	return groupID, nil
+}
+
+func (s *GroupService) mergeableGroup(groupID string) (*models.Group, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group %s not found", groupID)
+	}
+	if group.Archived {
+		return nil, fmt.Errorf("group %s is archived", groupID)
+	}
+	if group.ParentID != "" {
+		return nil, fmt.Errorf("group %s is a breakout and cannot be merged", groupID)
+	}
+	breakoutMembers, err := s.breakoutMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(breakoutMembers) > 0 {
+		return nil, fmt.Errorf("group %s has open breakouts", groupID)
+	}
+	meeting, err := s.store.GetOpenMeeting(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting != nil {
+		return nil, fmt.Errorf("group %s has a meeting in progress", groupID)
+	}
+	return group, nil
+}
+
+// redirectUserGroups points every active and recommended reference to a
+// merged group at the target. Members who were only waitlisted lose the
+// reference, and the target is never recommended to its own members.
+// moveWaitlist puts everyone still waiting for a merged group on the
+// target's waitlist, behind the people already there
+func (s *GroupService) moveWaitlist(sourceID string, target *models.Group) error {
+	entries, err := s.store.GetWaitlistByGroup(sourceID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistAdmitted {
+			continue
+		}
+		if isMember(target, entry.UserID) || isBanned(target, entry.UserID) {
+			continue
+		}
+		if _, err := s.joinWaitlist(target.ID, entry.UserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GroupService) redirectUserGroups(sourceIDs []string, target *models.Group) error {
+	merged := map[string]bool{}
+	for _, sourceID := range sourceIDs {
+		merged[sourceID] = true
+	}
+	redirect := func(groupIDs []string, keep bool) ([]string, bool) {
+		redirected := []string{}
+		changed, hasTarget := false, false
+		for _, groupID := range groupIDs {
+			if merged[groupID] {
+				changed = true
+				if !keep {
+					continue
+				}
+				groupID = target.ID
+			}
+			if groupID == target.ID {
+				if hasTarget {
+					changed = true
+					continue
+				}
+				hasTarget = true
+			}
+			redirected = append(redirected, groupID)
+		}
+		return redirected, changed
+	}
+
+	userGroups, err := s.store.GetAllUserGroups()
+	if err != nil {
+		return err
+	}
+	for _, userGroup := range userGroups {
+		member := isMember(target, userGroup.UserID)
+		activeGroups, activeChanged := redirect(userGroup.ActiveGroups, member)
+		recommendedGroups, recommendedChanged := redirect(userGroup.RecommendedGroups, !member)
+		if !activeChanged && !recommendedChanged {
+			continue
+		}
+		userGroup.ActiveGroups = activeGroups
+		userGroup.RecommendedGroups = recommendedGroups
+		if err := s.store.UpdateUserGroup(userGroup); err != nil {
+			return err
+		}
+		if recommendedChanged {
+			s.recommendationsChanged(userGroup)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxTimeout = 7 * 24 * time.Hour
+
+// KickMember removes a member from the group. They can join again later.
+func (s *GroupService) KickMember(groupID string, userID string, request *models.ModerationRequest) error {
+	group, reason, err := s.moderatedGroup(groupID, userID, request)
+	if err != nil {
+		return err
+	}
+	if !isMember(group, userID) {
+		return fmt.Errorf("user is not a member of this group")
+	}
+
+	if err := s.LeaveGroup(groupID, userID); err != nil {
+		return err
+	}
+	if err := s.postSystemMessage(groupID, fmt.Sprintf("%s was removed from the group", userID)); err != nil {
+		return err
+	}
+	return s.recordModeration(group, userID, models.ModerationKick, request.ActorID, reason, nil)
+}
+
+// BanMember removes a member if they are in the group and stops them from
+// joining, asking to join or waiting for a seat until they are unbanned
+func (s *GroupService) BanMember(groupID string, userID string, request *models.ModerationRequest) error {
+	group, reason, err := s.moderatedGroup(groupID, userID, request)
+	if err != nil {
+		return err
+	}
+	if isBanned(group, userID) {
+		return fmt.Errorf("user is already banned from this group")
+	}
+
+	if isMember(group, userID) {
+		if err := s.LeaveGroup(groupID, userID); err != nil {
+			return err
+		}
+		if err := s.postSystemMessage(groupID, fmt.Sprintf("%s was banned from the group", userID)); err != nil {
+			return err
+		}
+	}
+	group.Banned = append(group.Banned, userID)
+	delete(group.TimedOutUntil, userID)
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+
+	// Whatever they were waiting for is turned down
+	requests, err := s.store.GetJoinRequestsByGroup(groupID)
+	if err != nil {
+		return err
+	}
+	for _, joinRequest := range requests {
+		if joinRequest.UserID == userID && joinRequest.Status == models.JoinRequestPending {
+			if err := s.decideJoinRequest(joinRequest, models.JoinRequestRejected, request.ActorID); err != nil {
+				return err
+			}
+		}
+	}
+	position, err := s.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		return err
+	}
+	if position.Entry != nil {
+		admitted := position.Entry.Status == models.WaitlistAdmitted
+		position.Entry.Status = models.WaitlistLeft
+		if err := s.store.UpdateWaitlistEntry(position.Entry); err != nil {
+			return err
+		}
+		if admitted {
+			if err := s.admitFromWaitlist(groupID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.recordModeration(group, userID, models.ModerationBan, request.ActorID, reason, nil)
+}
+
+func (s *GroupService) UnbanMember(groupID string, userID string, request *models.ModerationRequest) error {
+	group, reason, err := s.moderatedGroup(groupID, userID, request)
+	if err != nil {
+		return err
+	}
+	if !isBanned(group, userID) {
+		return fmt.Errorf("user is not banned from this group")
+	}
+
+	group.Banned = removeID(group.Banned, userID)
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+	return s.recordModeration(group, userID, models.ModerationUnban, request.ActorID, reason, nil)
+}
+
+// TimeoutMember puts a member in read-only mode for a while: they stay in the
+// group but cannot post, act, answer, give kudos or schedule sessions
+func (s *GroupService) TimeoutMember(groupID string, userID string, request *models.ModerationRequest) (*time.Time, error) {
+	group, reason, err := s.moderatedGroup(groupID, userID, request)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("user is not a member of this group")
+	}
+	duration := time.Duration(request.DurationMinutes) * time.Minute
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration_minutes must be positive")
+	}
+	if duration > maxTimeout {
+		return nil, fmt.Errorf("timeouts can last at most %d days", int(maxTimeout.Hours()/24))
+	}
+
+	until := time.Now().Add(duration)
+	if group.TimedOutUntil == nil {
+		group.TimedOutUntil = map[string]time.Time{}
+	}
+	group.TimedOutUntil[userID] = until
+	if err := s.store.UpdateGroup(group); err != nil {
+		return nil, err
+	}
+	if err := s.recordModeration(group, userID, models.ModerationTimeout, request.ActorID, reason, &until); err != nil {
+		return nil, err
+	}
+	return &until, nil
+}
+
+func (s *GroupService) EndTimeout(groupID string, userID string, request *models.ModerationRequest) error {
+	group, reason, err := s.moderatedGroup(groupID, userID, request)
+	if err != nil {
+		return err
+	}
+	if checkCanPost(group, userID) == nil {
+		return fmt.Errorf("user is not timed out")
+	}
+
+	delete(group.TimedOutUntil, userID)
+	if err := s.store.UpdateGroup(group); err != nil {
+		return err
+	}
+	return s.recordModeration(group, userID, models.ModerationEndTimeout, request.ActorID, reason, nil)
+}
+
+// GetModerationState shows a group's bans and current timeouts to its owner,
+// its moderators and admins
+func (s *GroupService) GetModerationState(groupID string, actorID string) (*models.ModerationState, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) && requireAdmin(s.store, actorID) != nil {
+		return nil, fmt.Errorf("only the group owner, a moderator or an admin can see moderation state")
+	}
+
+	state := &models.ModerationState{
+		GroupID:       groupID,
+		Banned:        append([]string{}, group.Banned...),
+		TimedOutUntil: map[string]time.Time{},
+	}
+	now := time.Now()
+	for userID, until := range group.TimedOutUntil {
+		if now.Before(until) {
+			state.TimedOutUntil[userID] = until
+		}
+	}
+	return state, nil
+}
+
+// GetModerationActions returns the moderation audit trail to admins, newest
+// first, optionally for one group or one user
+func (s *GroupService) GetModerationActions(actorID string, groupID string, userID string, page models.PageRequest) (*models.Page[*models.ModerationAction], error) {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return nil, err
+	}
+	actions, err := s.store.GetModerationActions()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*models.ModerationAction{}
+	for _, action := range actions {
+		if (groupID == "" || action.GroupID == groupID) && (userID == "" || action.UserID == userID) {
+			filtered = append(filtered, action)
+		}
+	}
+	return paginate(filtered, "created", true, func(action *models.ModerationAction) (int64, string) {
+		return action.CreatedAt.UnixNano(), action.ID
+	}, page)
+}
+
+// moderatedGroup checks that the actor may moderate the user in the group.
+// Owners can moderate anyone, moderators only members without moderation
+// rights, and every action needs a reason.
+func (s *GroupService) moderatedGroup(groupID string, userID string, request *models.ModerationRequest) (*models.Group, string, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, "", err
+	}
+	if group == nil {
+		return nil, "", fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, request.ActorID) {
+		return nil, "", fmt.Errorf("only the group owner or a moderator can moderate members")
+	}
+	if userID == request.ActorID {
+		return nil, "", fmt.Errorf("members cannot moderate themselves")
+	}
+	if group.CreateBy == userID {
+		return nil, "", fmt.Errorf("the group owner cannot be moderated")
+	}
+	if isModerator(group, userID) && group.CreateBy != request.ActorID {
+		return nil, "", fmt.Errorf("only the group owner can moderate a moderator")
+	}
+
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		return nil, "", fmt.Errorf("a reason is required")
+	}
+	return group, reason, nil
+}
+
+func (s *GroupService) recordModeration(group *models.Group, userID string, actionType string, actorID string, reason string, until *time.Time) error {
+	return s.store.CreateModerationAction(&models.ModerationAction{
+		ID:        uuid.New().String(),
+		GroupID:   group.ID,
+		ActorID:   actorID,
+		UserID:    userID,
+		Type:      actionType,
+		Reason:    reason,
+		Until:     until,
+		CreatedAt: time.Now(),
+	})
+}
+
+func isBanned(group *models.Group, userID string) bool {
+	for _, bannedID := range group.Banned {
+		if bannedID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCanPost turns away members who are timed out of the group
+func checkCanPost(group *models.Group, userID string) error {
+	if until, exists := group.TimedOutUntil[userID]; exists && time.Now().Before(until) {
+		return fmt.Errorf("user is timed out of this group until %s", until.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
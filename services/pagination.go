@@ -0,0 +1,112 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// pageKey is the position of an item in a sorted list. Cursors carry the key of
+// the last item on a page rather than an offset, so items inserted or removed
+// before that point do not shift the following pages.
+type pageKey struct {
+	Sort  string `json:"s"`
+	Value int64  `json:"v"`
+	ID    string `json:"id"`
+}
+
+func encodeCursor(key pageKey) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, sortName string) (*pageKey, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var key pageKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if key.Sort != sortName {
+		return nil, fmt.Errorf("cursor was issued for a different sort order")
+	}
+	return &key, nil
+}
+
+// paginate orders items by key, with the ID breaking ties, and returns the page
+// following the cursor
+func paginate[T any](items []T, sortName string, descending bool, key func(T) (int64, string), request models.PageRequest) (*models.Page[T], error) {
+	after, err := decodeCursor(request.Cursor, sortName)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	// comesBefore reports whether (valueA, idA) sorts ahead of (valueB, idB)
+	comesBefore := func(valueA int64, idA string, valueB int64, idB string) bool {
+		if valueA != valueB {
+			return (valueA > valueB) == descending
+		}
+		return idA < idB
+	}
+
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		valueI, idI := key(sorted[i])
+		valueJ, idJ := key(sorted[j])
+		return comesBefore(valueI, idI, valueJ, idJ)
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(sorted), func(i int) bool {
+			value, id := key(sorted[i])
+			return comesBefore(after.Value, after.ID, value, id)
+		})
+	}
+
+	end := min(start+limit, len(sorted))
+	page := &models.Page[T]{Items: sorted[start:end]}
+	if end < len(sorted) {
+		value, id := key(sorted[end-1])
+		page.NextCursor = encodeCursor(pageKey{Sort: sortName, Value: value, ID: id})
+	}
+	return page, nil
+}
+
+// allPages follows a paged list's cursors to the end and returns every item
+func allPages[T any](list func(models.PageRequest) (*models.Page[T], error)) ([]T, error) {
+	items := []T{}
+	request := models.PageRequest{Limit: MaxPageLimit}
+	for {
+		page, err := list(request)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			return items, nil
+		}
+		request.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type pageItem struct {
+	id    string
+	value int64
+}
+
+func pageItemKey(item pageItem) (int64, string) {
+	return item.value, item.id
+}
+
+func pageItemIDs(items []pageItem) string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.id
+	}
+	return strings.Join(ids, ",")
+}
+
+// walkPages pages through items with the given limit and returns the IDs of
+// every page
+func walkPages(t *testing.T, items []pageItem, descending bool, limit int) []string {
+	t.Helper()
+	var pages []string
+	request := models.PageRequest{Limit: limit}
+	for len(pages) <= len(items) {
+		page, err := paginate(items, "test", descending, pageItemKey, request)
+		if err != nil {
+			t.Fatalf("paginate: %v", err)
+		}
+		pages = append(pages, pageItemIDs(page.Items))
+		if page.NextCursor == "" {
+			return pages
+		}
+		request.Cursor = page.NextCursor
+	}
+	t.Fatalf("paging never finished: %v", pages)
+	return nil
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  pageKey
+	}{
+		{"zero", pageKey{Sort: "activity", Value: 0, ID: "a"}},
+		{"negative", pageKey{Sort: "free_seats", Value: -3, ID: "group-1"}},
+		{"nanoseconds", pageKey{Sort: "newest", Value: 1792348965275123456, ID: "7579c015-6f88-461e-9fe8-d4f909d18eb2"}},
+		{"awkward id", pageKey{Sort: "members", Value: 12, ID: `a/b+c="d"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := encodeCursor(tt.key)
+			if strings.ContainsAny(cursor, "+/=") {
+				t.Fatalf("cursor %q is not URL safe", cursor)
+			}
+			got, err := decodeCursor(cursor, tt.key.Sort)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if *got != tt.key {
+				t.Fatalf("decodeCursor = %+v, want %+v", *got, tt.key)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+		sort   string
+		want   string
+	}{
+		{"not base64", "not a cursor!", "activity", "invalid cursor"},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("activity")), "activity", "invalid cursor"},
+		{"other sort", encodeCursor(pageKey{Sort: "newest", Value: 1, ID: "a"}), "activity", "different sort order"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeCursor(tt.cursor, tt.sort)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("decodeCursor error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorEmptyStartsAtTheBeginning(t *testing.T) {
+	key, err := decodeCursor("", "activity")
+	if err != nil || key != nil {
+		t.Fatalf("decodeCursor(\"\") = %v, %v, want nil, nil", key, err)
+	}
+}
+
+func TestPaginateOrdering(t *testing.T) {
+	items := []pageItem{
+		{"c", 5}, {"a", 5}, {"e", 1}, {"b", 9}, {"d", 5},
+	}
+	tests := []struct {
+		name       string
+		descending bool
+		limit      int
+		want       []string
+	}{
+		{"descending, ties by id", true, 2, []string{"b,a", "c,d", "e"}},
+		{"ascending, ties by id", false, 2, []string{"e,a", "c,d", "b"}},
+		{"one per page", true, 1, []string{"b", "a", "c", "d", "e"}},
+		{"page size matches the list", true, 5, []string{"b,a,c,d,e"}},
+		{"default limit", true, 0, []string{"b,a,c,d,e"}},
+		{"negative limit uses the default", false, -1, []string{"e,a,c,d,b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := walkPages(t, items, tt.descending, tt.limit)
+			if strings.Join(got, " | ") != strings.Join(tt.want, " | ") {
+				t.Fatalf("pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateDoesNotReorderInput(t *testing.T) {
+	items := []pageItem{{"b", 1}, {"a", 2}}
+	if _, err := paginate(items, "test", true, pageItemKey, models.PageRequest{}); err != nil {
+		t.Fatalf("paginate: %v", err)
+	}
+	if pageItemIDs(items) != "b,a" {
+		t.Fatalf("input was reordered to %s", pageItemIDs(items))
+	}
+}
+
+func TestPaginateClampsLimit(t *testing.T) {
+	items := make([]pageItem, MaxPageLimit+5)
+	for i := range items {
+		items[i] = pageItem{id: fmt.Sprintf("%04d", i), value: int64(i)}
+	}
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"default", 0, DefaultPageLimit},
+		{"asked for", 7, 7},
+		{"at most the maximum", MaxPageLimit * 2, MaxPageLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := paginate(items, "test", true, pageItemKey, models.PageRequest{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("paginate: %v", err)
+			}
+			if len(page.Items) != tt.want {
+				t.Fatalf("got %d items, want %d", len(page.Items), tt.want)
+			}
+			if page.NextCursor == "" {
+				t.Fatalf("expected a next cursor")
+			}
+		})
+	}
+}
+
+// Cursors remember the last item seen, so changes before it do not shift
+// the pages that follow
+func TestPaginateCursorSurvivesChanges(t *testing.T) {
+	items := []pageItem{{"a", 50}, {"b", 40}, {"c", 30}, {"d", 20}, {"e", 10}}
+	first, err := paginate(items, "test", true, pageItemKey, models.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("paginate: %v", err)
+	}
+	if pageItemIDs(first.Items) != "a,b" {
+		t.Fatalf("first page = %s, want a,b", pageItemIDs(first.Items))
+	}
+
+	tests := []struct {
+		name  string
+		items []pageItem
+		want  string
+	}{
+		{"unchanged", items, "c,d"},
+		{"added before the cursor", append([]pageItem{{"z", 60}}, items...), "c,d"},
+		{"removed before the cursor", items[1:], "c,d"},
+		{"cursor item removed", []pageItem{items[0], items[2], items[3], items[4]}, "c,d"},
+		{"added after the cursor", append([]pageItem{{"bb", 35}}, items...), "bb,c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := paginate(tt.items, "test", true, pageItemKey, models.PageRequest{Cursor: first.NextCursor, Limit: 2})
+			if err != nil {
+				t.Fatalf("paginate: %v", err)
+			}
+			if got := pageItemIDs(page.Items); got != tt.want {
+				t.Fatalf("second page = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllPagesCollectsEveryItem(t *testing.T) {
+	items := make([]pageItem, MaxPageLimit*2+3)
+	for i := range items {
+		items[i] = pageItem{id: fmt.Sprintf("%04d", i), value: int64(i)}
+	}
+	calls := 0
+	got, err := allPages(func(request models.PageRequest) (*models.Page[pageItem], error) {
+		calls++
+		return paginate(items, "test", false, pageItemKey, request)
+	})
+	if err != nil {
+		t.Fatalf("allPages: %v", err)
+	}
+	if pageItemIDs(got) != pageItemIDs(items) {
+		t.Fatalf("allPages returned %d items out of order or incomplete", len(got))
+	}
+	if calls != 3 {
+		t.Fatalf("allPages made %d calls, want 3", calls)
+	}
+}
+
+func TestListGroupsAllReturnsEveryGroup(t *testing.T) {
+	groups := make([]*models.Group, MaxPageLimit+5)
+	for i := range groups {
+		groups[i] = &models.Group{ID: fmt.Sprintf("%04d", i), ActivityScore: i}
+	}
+	s := &GroupService{}
+	page, err := s.ListGroups(groups, models.GroupListOptions{All: true, PageRequest: models.PageRequest{Limit: 2}})
+	if err != nil {
+		t.Fatalf("ListGroups: %v", err)
+	}
+	if len(page.Items) != len(groups) || page.NextCursor != "" {
+		t.Fatalf("got %d groups and cursor %q, want all %d and no cursor", len(page.Items), page.NextCursor, len(groups))
+	}
+	if page.Items[0].ID != groups[len(groups)-1].ID {
+		t.Fatalf("first group = %s, want the most active", page.Items[0].ID)
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods stops a rule without COUNT or UNTIL from expanding forever
+const maxRecurrencePeriods = 5000
+
+// recurrence is the subset of RFC 5545 RRULE the scheduler understands:
+// FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, COUNT, UNTIL and, for weekly
+// rules, BYDAY
+type recurrence struct {
+	freq     string
+	interval int
+	count    int
+	until    *time.Time
+	byDay    []time.Weekday
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func parseRRule(rule string, loc *time.Location) (*recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &recurrence{interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.freq = strings.ToUpper(value)
+			if r.freq != "DAILY" && r.freq != "WEEKLY" && r.freq != "MONTHLY" {
+				return nil, fmt.Errorf("rrule FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("rrule INTERVAL must be a positive number")
+			}
+			r.interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("rrule COUNT must be a positive number")
+			}
+			r.count = count
+		case "UNTIL":
+			until, err := parseRRuleUntil(value, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("rrule BYDAY only supports plain weekdays like MO,WE")
+				}
+				r.byDay = append(r.byDay, weekday)
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("rrule WKST must be MO")
+			}
+		default:
+			return nil, fmt.Errorf("rrule %s is not supported", name)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("rrule must have a FREQ")
+	}
+	if r.count > 0 && r.until != nil {
+		return nil, fmt.Errorf("rrule cannot have both COUNT and UNTIL")
+	}
+	if len(r.byDay) > 0 && r.freq != "WEEKLY" {
+		return nil, fmt.Errorf("rrule BYDAY is only supported for WEEKLY rules")
+	}
+	// Weeks start on Monday
+	sort.Slice(r.byDay, func(i, j int) bool {
+		return mondayIndex(r.byDay[i]) < mondayIndex(r.byDay[j])
+	})
+	return r, nil
+}
+
+// parseRRuleUntil accepts a UTC date-time, a floating local date-time or a date
+func parseRRuleUntil(value string, loc *time.Location) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return until, nil
+	}
+	if until, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return until.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("rrule UNTIL must look like 20261231 or 20261231T235959Z")
+}
+
+// wallClock is the given time of day on day's date. A time skipped by a
+// clock change is read with the offset from before the change, as RFC 5545
+// says, so 02:30 on a night the clocks jump from 02:00 to 03:00 is 03:30.
+func wallClock(day time.Time, hour int, minute int, second int, loc *time.Location) time.Time {
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc)
+	if h, m, s := t.Clock(); h == hour && m == minute && s == second {
+		return t
+	}
+	// Either offset around the gap may have been used; the earlier one is smaller
+	naive := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, time.UTC)
+	_, offset := t.Zone()
+	_, other := naive.Add(-time.Duration(offset) * time.Second).In(loc).Zone()
+	return naive.Add(-time.Duration(min(offset, other)) * time.Second).In(loc)
+}
+
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// occurrences lists up to limit start times of the rule that fall within
+// [from, to). Dates are worked out on the wall clock of start's location, so
+// a 6pm session stays at 6pm either side of a clock change.
+func (r *recurrence) occurrences(start time.Time, from time.Time, to time.Time, limit int) []time.Time {
+	var result []time.Time
+	seen := 0
+	hour, minute, second := start.Clock()
+	loc := start.Location()
+
+	emit := func(day time.Time) bool {
+		candidate := wallClock(day, hour, minute, second, loc)
+		if candidate.Before(start) {
+			return true
+		}
+		if r.until != nil && candidate.After(*r.until) {
+			return false
+		}
+		if !candidate.Before(to) {
+			return false
+		}
+		seen++
+		if r.count > 0 && seen > r.count {
+			return false
+		}
+		if !candidate.Before(from) {
+			result = append(result, candidate)
+		}
+		return limit <= 0 || len(result) < limit
+	}
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		step := period * r.interval
+		switch r.freq {
+		case "DAILY":
+			if !emit(start.AddDate(0, 0, step)) {
+				return result
+			}
+		case "WEEKLY":
+			if len(r.byDay) == 0 {
+				if !emit(start.AddDate(0, 0, 7*step)) {
+					return result
+				}
+				continue
+			}
+			monday := start.AddDate(0, 0, 7*step-mondayIndex(start.Weekday()))
+			for _, day := range r.byDay {
+				if !emit(monday.AddDate(0, 0, mondayIndex(day))) {
+					return result
+				}
+			}
+		case "MONTHLY":
+			// Months without the start's day are skipped, as RFC 5545 says
+			first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+			day := first.AddDate(0, 0, start.Day()-1)
+			if day.Month() != first.Month() {
+				continue
+			}
+			if !emit(day) {
+				return result
+			}
+		}
+	}
+	return result
+}
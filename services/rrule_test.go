@@ -0,0 +1,184 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseRRuleRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want string
+	}{
+		{"missing freq", "COUNT=3", "must have a FREQ"},
+		{"unsupported freq", "FREQ=YEARLY", "FREQ must be"},
+		{"count and until", "FREQ=DAILY;COUNT=3;UNTIL=20261231", "both COUNT and UNTIL"},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0", "INTERVAL must be"},
+		{"negative count", "FREQ=DAILY;COUNT=-1", "COUNT must be"},
+		{"byday on daily", "FREQ=DAILY;BYDAY=MO", "only supported for WEEKLY"},
+		{"ordinal byday", "FREQ=WEEKLY;BYDAY=1MO", "plain weekdays"},
+		{"bad until", "FREQ=DAILY;UNTIL=tomorrow", "UNTIL must look like"},
+		{"week start", "FREQ=WEEKLY;WKST=SU", "WKST must be MO"},
+		{"unknown part", "FREQ=DAILY;BYHOUR=9", "BYHOUR is not supported"},
+		{"empty value", "FREQ=", "invalid rrule part"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRRule(tt.rule, time.UTC)
+			if err == nil {
+				t.Fatalf("parseRRule(%q) succeeded, want error containing %q", tt.rule, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("parseRRule(%q) error = %q, want it to contain %q", tt.rule, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+	london := mustLoadLocation(t, "Europe/London")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		limit int
+		want  []string
+	}{
+		{
+			name:  "daily count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-21T18:00:00+05:30", "2026-10-22T18:00:00+05:30"},
+		},
+		{
+			name:  "rrule prefix and lower case",
+			rule:  "RRULE:freq=daily;count=2",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-21T18:00:00+05:30"},
+		},
+		{
+			name:  "until date covers the whole local day",
+			rule:  "FREQ=DAILY;UNTIL=20261022",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-21T18:00:00+05:30", "2026-10-22T18:00:00+05:30"},
+		},
+		{
+			name:  "until utc time is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20261021T123000Z",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-21T18:00:00+05:30"},
+		},
+		{
+			name:  "until local time",
+			rule:  "FREQ=DAILY;UNTIL=20261021T175959",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30"},
+		},
+		{
+			name:  "weekly byday",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-22T18:00:00+05:30", "2026-10-27T18:00:00+05:30", "2026-10-29T18:00:00+05:30"},
+		},
+		{
+			name:  "byday is put in week order",
+			rule:  "FREQ=WEEKLY;BYDAY=SU,MO;COUNT=3",
+			start: time.Date(2026, 10, 19, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-19T18:00:00+05:30", "2026-10-25T18:00:00+05:30", "2026-10-26T18:00:00+05:30"},
+		},
+		{
+			name:  "byday days before the start are not counted",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			start: time.Date(2026, 10, 21, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-22T18:00:00+05:30", "2026-10-27T18:00:00+05:30", "2026-10-29T18:00:00+05:30"},
+		},
+		{
+			name:  "weekly interval",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-11-03T18:00:00+05:30", "2026-11-17T18:00:00+05:30"},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: time.Date(2026, 1, 31, 9, 0, 0, 0, kolkata),
+			want:  []string{"2026-01-31T09:00:00+05:30", "2026-03-31T09:00:00+05:30", "2026-05-31T09:00:00+05:30"},
+		},
+		{
+			name:  "wall clock kept when clocks go back",
+			rule:  "FREQ=WEEKLY;COUNT=2",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, london),
+			want:  []string{"2026-10-20T18:00:00+01:00", "2026-10-27T18:00:00Z"},
+		},
+		{
+			name:  "wall clock kept when clocks go forward",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 3, 28, 18, 0, 0, 0, london),
+			want:  []string{"2026-03-28T18:00:00Z", "2026-03-29T18:00:00+01:00", "2026-03-30T18:00:00+01:00"},
+		},
+		{
+			name:  "time skipped by the clock change moves forward",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 3, 7, 2, 30, 0, 0, newYork),
+			want:  []string{"2026-03-07T02:30:00-05:00", "2026-03-08T03:30:00-04:00", "2026-03-09T02:30:00-04:00"},
+		},
+		{
+			name:  "window still counts earlier occurrences",
+			rule:  "FREQ=DAILY;COUNT=5",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			from:  time.Date(2026, 10, 22, 0, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-22T18:00:00+05:30", "2026-10-23T18:00:00+05:30", "2026-10-24T18:00:00+05:30"},
+		},
+		{
+			name:  "limit",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			limit: 2,
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-21T18:00:00+05:30"},
+		},
+		{
+			name:  "open ended rule stops at the end of the window",
+			rule:  "FREQ=WEEKLY",
+			start: time.Date(2026, 10, 20, 18, 0, 0, 0, kolkata),
+			to:    time.Date(2026, 11, 3, 18, 0, 0, 0, kolkata),
+			want:  []string{"2026-10-20T18:00:00+05:30", "2026-10-27T18:00:00+05:30"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.rule, tt.start.Location())
+			if err != nil {
+				t.Fatalf("parseRRule(%q): %v", tt.rule, err)
+			}
+			to := tt.to
+			if to.IsZero() {
+				to = tt.start.AddDate(1, 0, 0)
+			}
+			got := r.occurrences(tt.start, tt.from, to, tt.limit)
+
+			formatted := make([]string, len(got))
+			for i, occurrence := range got {
+				formatted[i] = occurrence.Format(time.RFC3339)
+			}
+			if strings.Join(formatted, " ") != strings.Join(tt.want, " ") {
+				t.Fatalf("occurrences = %v, want %v", formatted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"strings"
+	"time"
+
+	// Timezones are resolved from the embedded database so scheduling works
+	// on hosts without one installed
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSessionMinutes = 24 * 60
+	upcomingSessions  = 5
+	// calendarHorizon is how far ahead upcoming occurrences are looked for
+	calendarHorizon = 366 * 24 * time.Hour
+)
+
+// ScheduleSession adds a one-off or recurring session to a group's calendar.
+// Any member can organise one.
+func (s *GroupService) ScheduleSession(groupID string, request *models.SessionCreateRequest) (*models.StudySession, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Archived {
+		return nil, fmt.Errorf("group is archived")
+	}
+	if !isMember(group, request.UserID) {
+		return nil, fmt.Errorf("only group members can schedule sessions")
+	}
+	if err := checkCanPost(group, request.UserID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+	if len(title) > maxTitleLength {
+		return nil, fmt.Errorf("title cannot be longer than %d characters", maxTitleLength)
+	}
+	if request.DurationMinutes < 1 || request.DurationMinutes > maxSessionMinutes {
+		return nil, fmt.Errorf("duration must be between 1 and %d minutes", maxSessionMinutes)
+	}
+	loc, err := time.LoadLocation(request.Timezone)
+	if err != nil || request.Timezone == "" || request.Timezone == "Local" {
+		return nil, fmt.Errorf("unknown timezone %q", request.Timezone)
+	}
+	wall, err := time.Parse("2006-01-02T15:04", request.Start)
+	if err != nil {
+		return nil, fmt.Errorf("start must be a local time like 2026-10-20T18:00")
+	}
+	start := wallClock(wall, wall.Hour(), wall.Minute(), 0, loc)
+	rule := strings.TrimSpace(request.RRule)
+	if rule != "" {
+		if _, err := parseRRule(rule, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	session := &models.StudySession{
+		ID:              uuid.New().String(),
+		GroupID:         groupID,
+		Title:           title,
+		Description:     strings.TrimSpace(request.Description),
+		OrganizerID:     request.UserID,
+		Timezone:        request.Timezone,
+		Start:           start,
+		DurationMinutes: request.DurationMinutes,
+		RRule:           strings.TrimPrefix(rule, "RRULE:"),
+		CreatedAt:       time.Now(),
+	}
+	if err := s.store.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	// The organiser is going to their own session
+	rsvp := &models.SessionRSVP{
+		SessionID: session.ID,
+		GroupID:   groupID,
+		UserID:    request.UserID,
+		Response:  models.RSVPGoing,
+		UpdatedAt: session.CreatedAt,
+	}
+	if err := s.store.SetSessionRSVP(rsvp); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetGroupSessions lists a group's sessions by first start. Sessions of
+// private groups are only shown to members.
+func (s *GroupService) GetGroupSessions(groupID string, userID string, page models.PageRequest) (*models.Page[*models.StudySession], error) {
+	group, err := s.sessionGroup(groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.store.GetSessionsByGroup(group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []*models.StudySession{}
+	}
+	return paginate(sessions, "start", false, func(session *models.StudySession) (int64, string) {
+		return session.Start.UnixNano(), session.ID
+	}, page)
+}
+
+// GetSession returns a session with its next occurrences and the RSVPs so far
+func (s *GroupService) GetSession(groupID string, sessionID string, userID string) (*models.SessionDetails, error) {
+	if _, err := s.sessionGroup(groupID, userID); err != nil {
+		return nil, err
+	}
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.GroupID != groupID {
+		return nil, nil
+	}
+
+	rsvps, err := s.store.GetSessionRSVPs(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if rsvps == nil {
+		rsvps = []*models.SessionRSVP{}
+	}
+
+	now := time.Now()
+	return &models.SessionDetails{
+		Session:  session,
+		Upcoming: sessionOccurrences(session, now, now.Add(calendarHorizon), upcomingSessions),
+		RSVPs:    rsvps,
+	}, nil
+}
+
+// CancelSession removes a session from the calendar. The organiser, the owner
+// and moderators can cancel it.
+func (s *GroupService) CancelSession(groupID string, sessionID string, actorID string) error {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group not found")
+	}
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.GroupID != groupID {
+		return fmt.Errorf("session not found")
+	}
+	if session.OrganizerID != actorID && !canManageGroup(group, actorID) {
+		return fmt.Errorf("only the organiser, the group owner or a moderator can cancel a session")
+	}
+	return s.store.DeleteSession(sessionID)
+}
+
+// RSVPSession records whether a member is coming to a session
+func (s *GroupService) RSVPSession(groupID string, sessionID string, userID string, response string) (*models.SessionRSVP, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !isMember(group, userID) {
+		return nil, fmt.Errorf("only group members can RSVP")
+	}
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.GroupID != groupID {
+		return nil, fmt.Errorf("session not found")
+	}
+
+	response = strings.ToUpper(strings.TrimSpace(response))
+	switch response {
+	case models.RSVPGoing, models.RSVPMaybe, models.RSVPDeclined:
+	default:
+		return nil, fmt.Errorf("response must be %s, %s or %s", models.RSVPGoing, models.RSVPMaybe, models.RSVPDeclined)
+	}
+
+	rsvp := &models.SessionRSVP{
+		SessionID: sessionID,
+		GroupID:   groupID,
+		UserID:    userID,
+		Response:  response,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.store.SetSessionRSVP(rsvp); err != nil {
+		return nil, err
+	}
+	return rsvp, nil
+}
+
+// GroupCalendar renders a group's sessions as an iCalendar feed
+func (s *GroupService) GroupCalendar(groupID string, userID string) ([]byte, error) {
+	group, err := s.sessionGroup(groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.store.GetSessionsByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := newCalendar(group.Title)
+	for _, session := range sessions {
+		calendar.addSession(session, group)
+	}
+	return calendar.bytes(), nil
+}
+
+// UserCalendar renders the sessions of every group the user is in as an
+// iCalendar feed, leaving out the ones they declined
+func (s *GroupService) UserCalendar(userID string) ([]byte, error) {
+	user, err := s.store.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	userGroup, err := s.store.GetUserGroup(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := newCalendar("7cents study sessions")
+	if userGroup != nil {
+		groups, err := s.store.GetGroupsByIDs(userGroup.ActiveGroups)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			sessions, err := s.store.GetSessionsByGroup(group.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, session := range sessions {
+				rsvp, err := s.store.GetSessionRSVP(session.ID, userID)
+				if err != nil {
+					return nil, err
+				}
+				if rsvp != nil && rsvp.Response == models.RSVPDeclined {
+					continue
+				}
+				calendar.addSession(session, group)
+			}
+		}
+	}
+	return calendar.bytes(), nil
+}
+
+// sessionGroup loads a group whose sessions the user wants to see
+func (s *GroupService) sessionGroup(groupID string, userID string) (*models.Group, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if group.Private && !isMember(group, userID) {
+		return nil, fmt.Errorf("only members can see the sessions of a private group")
+	}
+	return group, nil
+}
+
+// sessionOccurrences lists up to limit occurrences of a session that have not
+// ended before from and start before to
+func sessionOccurrences(session *models.StudySession, from time.Time, to time.Time, limit int) []models.SessionOccurrence {
+	loc, err := time.LoadLocation(session.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	start := session.Start.In(loc)
+	duration := time.Duration(session.DurationMinutes) * time.Minute
+
+	starts := []time.Time{start}
+	if session.RRule != "" {
+		rule, err := parseRRule(session.RRule, loc)
+		if err != nil {
+			return []models.SessionOccurrence{}
+		}
+		starts = rule.occurrences(start, from.Add(-duration), to, limit)
+	}
+
+	occurrences := []models.SessionOccurrence{}
+	for _, occurrenceStart := range starts {
+		end := occurrenceStart.Add(duration)
+		if !end.After(from) || !occurrenceStart.Before(to) {
+			continue
+		}
+		occurrences = append(occurrences, models.SessionOccurrence{Start: occurrenceStart, End: end})
+		if len(occurrences) == limit {
+			break
+		}
+	}
+	return occurrences
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"fmt"
+	"strings"
+)
+
+type TaxonomyService struct {
+	store storage.Store
+}
+
+func NewTaxonomyService(store storage.Store) *TaxonomyService {
+	return &TaxonomyService{
+		store: store,
+	}
+}
+
+// GetTree returns the whole taxonomy as nested subjects, chapters and topics
+func (s *TaxonomyService) GetTree() ([]*models.TaxonomyTree, error) {
+	nodes, err := s.store.GetTaxonomyNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	trees := make(map[string]*models.TaxonomyTree, len(nodes))
+	for _, node := range nodes {
+		trees[node.ID] = &models.TaxonomyTree{TaxonomyNode: *node, Children: []*models.TaxonomyTree{}}
+	}
+
+	roots := []*models.TaxonomyTree{}
+	for _, node := range nodes {
+		tree := trees[node.ID]
+		if parent, exists := trees[node.ParentID]; exists {
+			parent.Children = append(parent.Children, tree)
+		} else {
+			roots = append(roots, tree)
+		}
+	}
+	return roots, nil
+}
+
+// CreateNode adds a subject, or a chapter or topic below an existing node. The
+// level follows from the parent.
+func (s *TaxonomyService) CreateNode(request *models.TaxonomyNodeRequest) (*models.TaxonomyNode, error) {
+	if err := requireAdmin(s.store, request.ActorID); err != nil {
+		return nil, err
+	}
+
+	name := strings.Join(strings.Fields(request.Name), " ")
+	slug := storage.TaxonomySlug(name)
+	if slug == "" || strings.Contains(slug, ".") {
+		return nil, fmt.Errorf("name must not be empty or contain dots")
+	}
+
+	node := &models.TaxonomyNode{
+		ID:    slug,
+		Name:  name,
+		Level: models.TaxonomySubject,
+	}
+	if request.ParentID != "" {
+		parent, err := s.store.GetTaxonomyNode(request.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("parent node not found")
+		}
+		switch parent.Level {
+		case models.TaxonomySubject:
+			node.Level = models.TaxonomyChapter
+		case models.TaxonomyChapter:
+			node.Level = models.TaxonomyTopic
+		default:
+			return nil, fmt.Errorf("topics cannot have children")
+		}
+		node.ID = parent.ID + "." + slug
+		node.ParentID = parent.ID
+	}
+
+	existing, err := s.store.GetTaxonomyNode(node.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("taxonomy node %s already exists", node.ID)
+	}
+
+	if err := s.store.CreateTaxonomyNode(node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// DeleteNode removes a node that has no children and no groups tagged with it
+func (s *TaxonomyService) DeleteNode(nodeID string, actorID string) error {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return err
+	}
+
+	node, err := s.store.GetTaxonomyNode(nodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("taxonomy node not found")
+	}
+
+	nodes, err := s.store.GetTaxonomyNodes()
+	if err != nil {
+		return err
+	}
+	for _, other := range nodes {
+		if other.ParentID == nodeID {
+			return fmt.Errorf("taxonomy node has children")
+		}
+	}
+
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		for _, tag := range group.Tags {
+			if tag == nodeID {
+				return fmt.Errorf("taxonomy node is still used by group %s", group.ID)
+			}
+		}
+	}
+
+	return s.store.DeleteTaxonomyNode(nodeID)
+}
+
+// validateTaxonomyTags checks that every tag is a known taxonomy node and
+// returns them without duplicates
+func validateTaxonomyTags(store storage.Store, tags []string) ([]string, error) {
+	seen := make(map[string]bool)
+	valid := []string{}
+	for _, tag := range tags {
+		if seen[tag] {
+			continue
+		}
+		node, err := store.GetTaxonomyNode(tag)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("unknown taxonomy tag %s", tag)
+		}
+		seen[tag] = true
+		valid = append(valid, tag)
+	}
+	return valid, nil
+}
+
+// canonicalTag normalises a free-text tag and resolves synonyms to their canonical form
+func canonicalTag(store storage.Store, tag string) (string, error) {
+	tag = models.NormalizeTag(tag)
+	synonym, err := store.GetTagSynonym(tag)
+	if err != nil {
+		return "", err
+	}
+	if synonym != nil {
+		return synonym.Canonical, nil
+	}
+	return tag, nil
+}
+
+// GetSynonyms pages the synonym dictionary in alias order
+func (s *TaxonomyService) GetSynonyms(page models.PageRequest) (*models.Page[*models.TagSynonym], error) {
+	synonyms, err := s.store.GetTagSynonyms()
+	if err != nil {
+		return nil, err
+	}
+	return paginate(synonyms, "alias", false, func(synonym *models.TagSynonym) (int64, string) {
+		return 0, synonym.Alias
+	}, page)
+}
+
+// SetSynonym maps an alias onto a canonical tag. Chains are flattened so every
+// alias points straight at a tag that is not itself an alias.
+func (s *TaxonomyService) SetSynonym(request *models.TagSynonymRequest) (*models.TagSynonym, error) {
+	if err := requireAdmin(s.store, request.ActorID); err != nil {
+		return nil, err
+	}
+
+	alias := models.NormalizeTag(request.Alias)
+	canonical, err := canonicalTag(s.store, request.Canonical)
+	if err != nil {
+		return nil, err
+	}
+	if alias == "" || canonical == "" {
+		return nil, fmt.Errorf("alias and canonical tag cannot be empty")
+	}
+	if alias == canonical {
+		return nil, fmt.Errorf("a tag cannot be a synonym of itself")
+	}
+
+	synonyms, err := s.store.GetTagSynonyms()
+	if err != nil {
+		return nil, err
+	}
+	for _, synonym := range synonyms {
+		if synonym.Canonical == alias {
+			return nil, fmt.Errorf("%s is the canonical tag for %s and cannot become an alias", alias, synonym.Alias)
+		}
+	}
+
+	synonym := &models.TagSynonym{Alias: alias, Canonical: canonical}
+	if err := s.store.SetTagSynonym(synonym); err != nil {
+		return nil, err
+	}
+	return synonym, nil
+}
+
+func (s *TaxonomyService) DeleteSynonym(alias string, actorID string) error {
+	if err := requireAdmin(s.store, actorID); err != nil {
+		return err
+	}
+
+	alias = models.NormalizeTag(alias)
+	synonym, err := s.store.GetTagSynonym(alias)
+	if err != nil {
+		return err
+	}
+	if synonym == nil {
+		return fmt.Errorf("synonym not found")
+	}
+	return s.store.DeleteTagSynonym(alias)
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkBatches caps how many groups one bulk request can create
+const MaxBulkBatches = 100
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// CreateTemplate saves a reusable group configuration. The fixed parts are
+// checked against the group type's rules straight away so that mistakes
+// surface before any group is made from it.
+func (s *GroupService) CreateTemplate(request *models.GroupTemplateRequest) (*models.GroupTemplate, error) {
+	user, err := s.store.GetUser(request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	groupType := s.groupTypes.Lookup(request.Type)
+	if groupType == nil {
+		return nil, fmt.Errorf("unknown group type %q", request.Type)
+	}
+	if groupType.Creators == models.CreatorsSystem {
+		return nil, fmt.Errorf("%s groups can only be created by the system", groupType.Name)
+	}
+	if request.Capacity != 0 {
+		if err := checkCapacity(groupType, request.Capacity); err != nil {
+			return nil, err
+		}
+	}
+	if request.JoinPolicy != "" {
+		if err := checkJoinPolicy(groupType, request.JoinPolicy); err != nil {
+			return nil, err
+		}
+	}
+	tags, err := validateTaxonomyTags(s.store, request.Tags)
+	if err != nil {
+		return nil, err
+	}
+	questions := []models.TemplateQuestion{}
+	for _, question := range request.Questions {
+		if strings.TrimSpace(question.Content) == "" {
+			return nil, fmt.Errorf("questions cannot be empty")
+		}
+		if len(question.Options) < 2 {
+			return nil, fmt.Errorf("questions need at least two options")
+		}
+		if question.CorrectOption != nil && (*question.CorrectOption < 0 || *question.CorrectOption >= len(question.Options)) {
+			return nil, fmt.Errorf("correct option must be the index of one of the question's options")
+		}
+		questions = append(questions, question)
+	}
+
+	welcome := strings.TrimSpace(request.WelcomeMessage)
+	if welcome == "" {
+		welcome = defaultWelcomeMessage
+	}
+	template := &models.GroupTemplate{
+		ID:             uuid.New().String(),
+		Name:           name,
+		Title:          strings.TrimSpace(request.Title),
+		Description:    strings.TrimSpace(request.Description),
+		WelcomeMessage: welcome,
+		Tag:            strings.TrimSpace(request.Tag),
+		Tags:           tags,
+		Type:           groupType.Name,
+		Capacity:       request.Capacity,
+		Private:        request.Private,
+		JoinPolicy:     request.JoinPolicy,
+		Questions:      questions,
+		CreatedBy:      request.ActorID,
+		CreatedAt:      time.Now(),
+	}
+	template.Parameters = templateParameters(template)
+	if err := s.store.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *GroupService) GetTemplate(templateID string) (*models.GroupTemplate, error) {
+	return s.store.GetTemplate(templateID)
+}
+
+func (s *GroupService) GetTemplates(page models.PageRequest) (*models.Page[*models.GroupTemplate], error) {
+	templates, err := s.store.GetTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return paginate(templates, "created", false, func(template *models.GroupTemplate) (int64, string) {
+		return template.CreatedAt.UnixNano(), template.ID
+	}, page)
+}
+
+// DeleteTemplate removes a template. Groups made from it are left alone.
+func (s *GroupService) DeleteTemplate(templateID string, actorID string) error {
+	template, err := s.store.GetTemplate(templateID)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return fmt.Errorf("template not found")
+	}
+	if template.CreatedBy != actorID && requireAdmin(s.store, actorID) != nil {
+		return fmt.Errorf("only the template's creator or an admin can delete it")
+	}
+	return s.store.DeleteTemplate(templateID)
+}
+
+// InstantiateTemplate makes a group owned by the user from a template,
+// filling in its placeholders from params
+func (s *GroupService) InstantiateTemplate(templateID string, userID string, params map[string]string) (*models.Group, error) {
+	template, err := s.store.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, fmt.Errorf("template not found")
+	}
+	group, welcome, err := s.renderTemplate(template, params)
+	if err != nil {
+		return nil, err
+	}
+	group.CreateBy = userID
+	if err := s.createGroup(group, welcome); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// BulkInstantiateTemplate makes one group per batch. Every batch is checked
+// for missing placeholders before any group is made; groups that then fail
+// to be created are reported without stopping the rest.
+func (s *GroupService) BulkInstantiateTemplate(templateID string, userID string, batches []map[string]string) (*models.BulkCreateResult, error) {
+	template, err := s.store.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, fmt.Errorf("template not found")
+	}
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("at least one batch is required")
+	}
+	if len(batches) > MaxBulkBatches {
+		return nil, fmt.Errorf("at most %d batches can be created at once", MaxBulkBatches)
+	}
+
+	groups := make([]*models.Group, len(batches))
+	welcomes := make([]string, len(batches))
+	for i, params := range batches {
+		group, welcome, err := s.renderTemplate(template, params)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %v", i, err)
+		}
+		group.CreateBy = userID
+		groups[i] = group
+		welcomes[i] = welcome
+	}
+
+	result := &models.BulkCreateResult{
+		Created: []*models.Group{},
+		Failed:  []models.BulkCreateError{},
+	}
+	for i, group := range groups {
+		if err := s.createGroup(group, welcomes[i]); err != nil {
+			result.Failed = append(result.Failed, models.BulkCreateError{Index: i, Error: err.Error()})
+			continue
+		}
+		result.Created = append(result.Created, group)
+	}
+	return result, nil
+}
+
+// CloneGroup makes a new group with the same configuration and questions as
+// an existing one, owned by the user. Members, messages and history stay behind.
+func (s *GroupService) CloneGroup(groupID string, userID string, title string) (*models.Group, error) {
+	source, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(source, userID) && requireAdmin(s.store, userID) != nil {
+		return nil, fmt.Errorf("only the group owner, a moderator or an admin can clone a group")
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = source.Title
+	}
+	if len(title) > maxTitleLength {
+		return nil, fmt.Errorf("title cannot be longer than %d characters", maxTitleLength)
+	}
+	welcome := defaultWelcomeMessage
+	if len(source.Messages) > 0 && source.Messages[0].SenderId == "system" {
+		welcome = source.Messages[0].Content
+	}
+
+	now := time.Now()
+	questions := make([]models.Question, len(source.Questions))
+	for i, question := range source.Questions {
+		questions[i] = models.Question{
+			ID:            uuid.New().String(),
+			Content:       question.Content,
+			Options:       append([]string{}, question.Options...),
+			Timestamp:     now,
+			CorrectOption: question.CorrectOption,
+		}
+	}
+	clone := &models.Group{
+		Title:       title,
+		Description: source.Description,
+		Tag:         source.Tag,
+		Tags:        append([]string{}, source.Tags...),
+		Type:        source.Type,
+		Private:     source.Private,
+		JoinPolicy:  source.JoinPolicy,
+		Capacity:    source.Capacity,
+		Questions:   questions,
+		CreateBy:    userID,
+	}
+	if err := s.createGroup(clone, welcome); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// renderTemplate fills in a template's placeholders and returns the group to
+// create along with its welcome message
+func (s *GroupService) renderTemplate(template *models.GroupTemplate, params map[string]string) (*models.Group, string, error) {
+	var missing []string
+	render := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			name := placeholderPattern.FindStringSubmatch(match)[1]
+			value, ok := params[name]
+			if !ok || strings.TrimSpace(value) == "" {
+				missing = append(missing, name)
+				return match
+			}
+			return strings.TrimSpace(value)
+		})
+	}
+
+	now := time.Now()
+	group := &models.Group{
+		Title:       render(template.Title),
+		Description: render(template.Description),
+		Tag:         render(template.Tag),
+		Tags:        append([]string{}, template.Tags...),
+		Type:        template.Type,
+		Capacity:    template.Capacity,
+		JoinPolicy:  template.JoinPolicy,
+		Questions:   make([]models.Question, len(template.Questions)),
+	}
+	for i, question := range template.Questions {
+		options := make([]string, len(question.Options))
+		for j, option := range question.Options {
+			options[j] = render(option)
+		}
+		group.Questions[i] = models.Question{
+			ID:            uuid.New().String(),
+			Content:       render(question.Content),
+			Options:       options,
+			Timestamp:     now,
+			CorrectOption: question.CorrectOption,
+		}
+	}
+	welcome := render(template.WelcomeMessage)
+
+	if len(missing) > 0 {
+		return nil, "", fmt.Errorf("missing values for placeholders: %s", strings.Join(uniqueSorted(missing), ", "))
+	}
+	if group.Title == "" {
+		return nil, "", fmt.Errorf("title cannot be empty")
+	}
+	if len(group.Title) > maxTitleLength {
+		return nil, "", fmt.Errorf("title cannot be longer than %d characters", maxTitleLength)
+	}
+
+	// Like a new group, privacy defaults to the group type's when the template leaves it out
+	if template.Private != nil {
+		group.Private = *template.Private
+	} else if groupType := s.groupTypes.Lookup(template.Type); groupType != nil {
+		group.Private = groupType.DefaultPrivate
+	}
+	return group, welcome, nil
+}
+
+// templateParameters lists the placeholder names used anywhere in a template
+func templateParameters(template *models.GroupTemplate) []string {
+	texts := []string{template.Title, template.Description, template.WelcomeMessage, template.Tag}
+	for _, question := range template.Questions {
+		texts = append(texts, question.Content)
+		texts = append(texts, question.Options...)
+	}
+	var names []string
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			names = append(names, match[1])
+		}
+	}
+	return uniqueSorted(names)
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+type UserService struct {
+	store storage.Store
+}
+
+func NewUserService(store storage.Store) *UserService {
+	return &UserService{
+		store: store,
+	}
+}
+
+// ExportUserData gathers the profile, memberships, messages, actions, matches,
+// recommendations, rejections, invites, join requests, session RSVPs, attendance,
+// answers and kudos of a user. It returns nil if the user does not exist.
+func (s *UserService) ExportUserData(userID string) (*models.UserDataExport, error) {
+	user, err := s.store.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	export := &models.UserDataExport{
+		GeneratedAt:     time.Now(),
+		Profile:         *user,
+		Memberships:     []models.ExportedMembership{},
+		Messages:        []models.ExportedMessage{},
+		Actions:         []models.ExportedAction{},
+		Matches:         []models.ExportedMatch{},
+		Recommendations: []models.ExportedGroupReference{},
+		Rejections:      []models.ExportedGroupReference{},
+		Invites:         []models.Invite{},
+		JoinRequests:    []models.JoinRequest{},
+		RSVPs:           []models.SessionRSVP{},
+		Attendance:      []models.AttendanceRecord{},
+		Answers:         []models.QuestionAnswer{},
+		Kudos:           []models.Kudos{},
+	}
+
+	// Walk every group so that messages sent to groups the user has since left are included
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		for _, memberID := range group.Members {
+			if memberID == userID {
+				export.Memberships = append(export.Memberships, models.ExportedMembership{
+					GroupID: group.ID,
+					Title:   group.Title,
+					Tag:     group.Tag,
+					Type:    group.Type,
+					Owner:   group.CreateBy == userID,
+				})
+				break
+			}
+		}
+		for _, message := range group.Messages {
+			if message.SenderId == userID {
+				export.Messages = append(export.Messages, models.ExportedMessage{GroupID: group.ID, Message: message})
+			}
+		}
+		for _, action := range group.Actions {
+			if action.SenderId == userID {
+				export.Actions = append(export.Actions, models.ExportedAction{GroupID: group.ID, Action: action})
+			}
+		}
+	}
+
+	for _, match := range s.store.GetMatches(userID) {
+		other := match.User2
+		if other.ID == userID {
+			other = match.User1
+		}
+		export.Matches = append(export.Matches, models.ExportedMatch{
+			UserID:     other.ID,
+			Name:       other.Name,
+			Similarity: match.Similarity,
+		})
+	}
+
+	userGroup, err := s.store.GetUserGroup(userID)
+	if err != nil {
+		return nil, err
+	}
+	if userGroup != nil {
+		if export.Recommendations, err = s.groupReferences(userGroup.RecommendedGroups); err != nil {
+			return nil, err
+		}
+		if export.Rejections, err = s.groupReferences(userGroup.RejectedGroups); err != nil {
+			return nil, err
+		}
+	}
+
+	invites, err := s.store.GetInvitesForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, invite := range invites {
+		export.Invites = append(export.Invites, *invite)
+	}
+
+	joinRequests, err := s.store.GetJoinRequestsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range joinRequests {
+		export.JoinRequests = append(export.JoinRequests, *request)
+	}
+
+	rsvps, err := s.store.GetSessionRSVPsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rsvp := range rsvps {
+		export.RSVPs = append(export.RSVPs, *rsvp)
+	}
+
+	attendance, err := s.store.GetAttendanceByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range attendance {
+		export.Attendance = append(export.Attendance, *record)
+	}
+
+	answers, err := s.store.GetQuestionAnswersByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		export.Answers = append(export.Answers, *answer)
+	}
+
+	kudos, err := s.store.GetKudosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, given := range kudos {
+		export.Kudos = append(export.Kudos, *given)
+	}
+
+	return export, nil
+}
+
+// groupReferences resolves group titles where the group still exists
+func (s *UserService) groupReferences(groupIDs []string) ([]models.ExportedGroupReference, error) {
+	references := make([]models.ExportedGroupReference, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		reference := models.ExportedGroupReference{GroupID: groupID}
+		group, err := s.store.GetGroup(groupID)
+		if err != nil {
+			return nil, err
+		}
+		if group != nil {
+			reference.Title = group.Title
+		}
+		references = append(references, reference)
+	}
+	return references, nil
+}
+
+// BuildExportArchive packages an export as a zip archive with one JSON file per section
+func (s *UserService) BuildExportArchive(export *models.UserDataExport) ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"scores.json", export.Profile.Score},
+		{"memberships.json", export.Memberships},
+		{"messages.json", export.Messages},
+		{"actions.json", export.Actions},
+		{"matches.json", export.Matches},
+		{"recommendations.json", export.Recommendations},
+		{"rejections.json", export.Rejections},
+		{"invites.json", export.Invites},
+		{"join_requests.json", export.JoinRequests},
+		{"rsvps.json", export.RSVPs},
+		{"attendance.json", export.Attendance},
+		{"answers.json", export.Answers},
+		{"kudos.json", export.Kudos},
+		{"export.json", export},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
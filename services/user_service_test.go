@@ -0,0 +1,51 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"allen_hackathon/storage"
+	"testing"
+)
+
+func TestExportUserDataIncludesActionsTheUserPosted(t *testing.T) {
+	store := storage.NewMemoryStore()
+	groups := NewGroupService(store)
+	users := NewUserService(store)
+
+	group := &models.Group{Title: "Thermo", Type: models.GroupTypeStudy, CreateBy: "1", Tag: "Physics", Capacity: 10}
+	if err := groups.CreateGroup(group); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := groups.JoinGroup(group.ID, "2"); err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if err := groups.UpdateGroup(group.ID, &models.GroupUpdateRequest{
+		UserID: "1",
+		Action: &models.ActionUpdate{Type: models.ActionTypeTest, Content: "Chapter 3 quiz"},
+	}); err != nil {
+		t.Fatalf("UpdateGroup: %v", err)
+	}
+
+	tests := []struct {
+		userID      string
+		wantActions int
+	}{
+		{"1", 1},
+		{"2", 0},
+	}
+	for _, tt := range tests {
+		t.Run("user "+tt.userID, func(t *testing.T) {
+			export, err := users.ExportUserData(tt.userID)
+			if err != nil {
+				t.Fatalf("ExportUserData: %v", err)
+			}
+			if len(export.Actions) != tt.wantActions {
+				t.Fatalf("exported %d actions, want %d", len(export.Actions), tt.wantActions)
+			}
+			for _, exported := range export.Actions {
+				if exported.GroupID != group.ID || exported.Action.Content != "Chapter 3 quiz" || exported.Action.SenderId != tt.userID {
+					t.Fatalf("unexpected exported action %+v", exported)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"allen_hackathon/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWaitlistConfirmWindow is how long a user admitted from the waitlist
+// has to confirm their seat before it goes to the next person in line
+const DefaultWaitlistConfirmWindow = 24 * time.Hour
+
+func isFull(group *models.Group) bool {
+	return len(group.Members) >= group.Capacity
+}
+
+// joinWaitlist queues the user for the group, or returns their current place
+// if they are already queued. Banned users cannot queue.
+func (s *GroupService) joinWaitlist(groupID string, userID string) (*models.WaitlistPosition, error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group != nil && isBanned(group, userID) {
+		return nil, fmt.Errorf("user is banned from this group")
+	}
+
+	position, err := s.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if position.Entry != nil {
+		return position, nil
+	}
+
+	entry := &models.WaitlistEntry{
+		ID:       uuid.New().String(),
+		GroupID:  groupID,
+		UserID:   userID,
+		Status:   models.WaitlistWaiting,
+		JoinedAt: time.Now(),
+	}
+	if err := s.store.CreateWaitlistEntry(entry); err != nil {
+		return nil, err
+	}
+	return s.GetWaitlistPosition(groupID, userID)
+}
+
+// GetWaitlistPosition returns the user's open waitlist entry for the group
+// and their 1-based place in the queue while they are still waiting
+func (s *GroupService) GetWaitlistPosition(groupID string, userID string) (*models.WaitlistPosition, error) {
+	entries, err := s.store.GetWaitlistByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := &models.WaitlistPosition{}
+	for _, entry := range entries {
+		if entry.Status == models.WaitlistWaiting {
+			position.Waiting++
+			if entry.UserID == userID {
+				position.Entry = entry
+				position.Position = position.Waiting
+			}
+		}
+		if entry.Status == models.WaitlistAdmitted && entry.UserID == userID {
+			position.Entry = entry
+		}
+	}
+	return position, nil
+}
+
+func (s *GroupService) GetWaitlist(groupID string, actorID string, page models.PageRequest) (*models.Page[*models.WaitlistEntry], error) {
+	group, err := s.store.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+	if !canManageGroup(group, actorID) {
+		return nil, fmt.Errorf("only the group owner or a moderator can view the waitlist")
+	}
+
+	entries, err := s.store.GetWaitlistByGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	open := []*models.WaitlistEntry{}
+	for _, entry := range entries {
+		if entry.Status == models.WaitlistWaiting || entry.Status == models.WaitlistAdmitted {
+			open = append(open, entry)
+		}
+	}
+	return paginate(open, "queue", false, func(entry *models.WaitlistEntry) (int64, string) {
+		return entry.JoinedAt.UnixNano(), entry.ID
+	}, page)
+}
+
+// GetUserWaitlists returns every queue the user is currently in with their position
+func (s *GroupService) GetUserWaitlists(userID string, page models.PageRequest) (*models.Page[*models.WaitlistPosition], error) {
+	entries, err := s.store.GetWaitlistByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := []*models.WaitlistPosition{}
+	for _, entry := range entries {
+		if entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistAdmitted {
+			continue
+		}
+		position, err := s.GetWaitlistPosition(entry.GroupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+	return paginate(positions, "queue", false, func(position *models.WaitlistPosition) (int64, string) {
+		return position.Entry.JoinedAt.UnixNano(), position.Entry.ID
+	}, page)
+}
+
+// ConfirmWaitlistSeat keeps the seat a user was admitted to from the waitlist
+func (s *GroupService) ConfirmWaitlistSeat(groupID string, userID string) (*models.WaitlistEntry, error) {
+	position, err := s.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	entry := position.Entry
+	if entry == nil || entry.Status != models.WaitlistAdmitted {
+		return nil, fmt.Errorf("user has no seat waiting for confirmation in this group")
+	}
+	if entry.ConfirmBy != nil && time.Now().After(*entry.ConfirmBy) {
+		return nil, fmt.Errorf("the confirmation window has passed")
+	}
+
+	now := time.Now()
+	entry.Status = models.WaitlistConfirmed
+	entry.ConfirmedAt = &now
+	return entry, s.store.UpdateWaitlistEntry(entry)
+}
+
+// LeaveWaitlist drops the user from the queue. A user who was already admitted
+// gives up their seat, which is offered to the next person in line.
+func (s *GroupService) LeaveWaitlist(groupID string, userID string) error {
+	position, err := s.GetWaitlistPosition(groupID, userID)
+	if err != nil {
+		return err
+	}
+	entry := position.Entry
+	if entry == nil {
+		return fmt.Errorf("user is not on the waitlist for this group")
+	}
+
+	wasAdmitted := entry.Status == models.WaitlistAdmitted
+	entry.Status = models.WaitlistLeft
+	if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+		return err
+	}
+	if wasAdmitted {
+		return s.LeaveGroup(groupID, userID)
+	}
+	return nil
+}
+
+// admitFromWaitlist fills free seats with the first users in the queue.
+// Nobody can join an archived or expired group, so its queue is expired
+// instead, and banned users are taken off the queue.
+func (s *GroupService) admitFromWaitlist(groupID string) error {
+	entries, err := s.store.GetWaitlistByGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Status != models.WaitlistWaiting {
+			continue
+		}
+
+		group, err := s.store.GetGroup(groupID)
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			return nil
+		}
+		if group.Archived || hasExpired(group, time.Now()) {
+			entry.Status = models.WaitlistExpired
+			if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+				return err
+			}
+			continue
+		}
+		if isFull(group) {
+			return nil
+		}
+
+		if isMember(group, entry.UserID) || isBanned(group, entry.UserID) {
+			entry.Status = models.WaitlistLeft
+			if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.JoinGroup(groupID, entry.UserID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		confirmBy := now.Add(s.waitlistConfirmWindow)
+		entry.Status = models.WaitlistAdmitted
+		entry.AdmittedAt = &now
+		entry.ConfirmBy = &confirmBy
+		if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+			return err
+		}
+
+		message := models.Message{
+			ID:        uuid.New().String(),
+			Content:   fmt.Sprintf("%s joined from the waitlist and has until %s to confirm their seat", entry.UserID, confirmBy.Format(time.RFC1123)),
+			SenderId:  "system",
+			Timestamp: now,
+		}
+		if err := s.addMessage(groupID, &message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExpireWaitlistAdmissions removes admitted users who did not confirm in time
+// and hands their seats to the next people in line
+func (s *GroupService) ExpireWaitlistAdmissions() error {
+	groups, err := s.store.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		entries, err := s.store.GetWaitlistByGroup(group.ID)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Status != models.WaitlistAdmitted || entry.ConfirmBy == nil || now.Before(*entry.ConfirmBy) {
+				continue
+			}
+
+			entry.Status = models.WaitlistExpired
+			if err := s.store.UpdateWaitlistEntry(entry); err != nil {
+				return err
+			}
+			if isMember(group, entry.UserID) {
+				if err := s.LeaveGroup(group.ID, entry.UserID); err != nil {
+					return err
+				}
+			} else if err := s.admitFromWaitlist(group.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
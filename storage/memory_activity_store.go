@@ -0,0 +1,14 @@
+package storage
+
+import "allen_hackathon/models"
+
+// Membership event operations
+func (s *MemoryStore) AddMembershipEvent(event *models.MembershipEvent) error {
+	s.memberships[event.GroupID] = append(s.memberships[event.GroupID], event)
+	return nil
+}
+
+// GetMembershipEvents returns a group's joins and leaves in the order they happened
+func (s *MemoryStore) GetMembershipEvents(groupID string) ([]*models.MembershipEvent, error) {
+	return s.memberships[groupID], nil
+}
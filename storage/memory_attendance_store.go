@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Attendance operations
+func (s *MemoryStore) CreateAttendanceRecord(record *models.AttendanceRecord) error {
+	s.attendance[record.ID] = record
+	return nil
+}
+
+func (s *MemoryStore) GetAttendanceByGroup(groupID string) ([]*models.AttendanceRecord, error) {
+	var records []*models.AttendanceRecord
+	for _, record := range s.attendance {
+		if record.GroupID == groupID {
+			records = append(records, record)
+		}
+	}
+	sortAttendance(records)
+	return records, nil
+}
+
+func (s *MemoryStore) GetAttendanceByUser(userID string) ([]*models.AttendanceRecord, error) {
+	var records []*models.AttendanceRecord
+	for _, record := range s.attendance {
+		if record.UserID == userID {
+			records = append(records, record)
+		}
+	}
+	sortAttendance(records)
+	return records, nil
+}
+
+// sortAttendance orders records by when the member joined
+func sortAttendance(records []*models.AttendanceRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].JoinedAt.Equal(records[j].JoinedAt) {
+			return records[i].ID < records[j].ID
+		}
+		return records[i].JoinedAt.Before(records[j].JoinedAt)
+	})
+}
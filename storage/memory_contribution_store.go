@@ -0,0 +1,65 @@
+package storage
+
+import "allen_hackathon/models"
+
+// Question answer operations
+func (s *MemoryStore) CreateQuestionAnswer(answer *models.QuestionAnswer) error {
+	s.answers = append(s.answers, answer)
+	return nil
+}
+
+func (s *MemoryStore) GetQuestionAnswer(groupID string, questionID string, userID string) (*models.QuestionAnswer, error) {
+	for _, answer := range s.answers {
+		if answer.GroupID == groupID && answer.QuestionID == questionID && answer.UserID == userID {
+			return answer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetQuestionAnswersByGroup(groupID string) ([]*models.QuestionAnswer, error) {
+	var answers []*models.QuestionAnswer
+	for _, answer := range s.answers {
+		if answer.GroupID == groupID {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+func (s *MemoryStore) GetQuestionAnswersByUser(userID string) ([]*models.QuestionAnswer, error) {
+	var answers []*models.QuestionAnswer
+	for _, answer := range s.answers {
+		if answer.UserID == userID {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+// Kudos operations
+func (s *MemoryStore) CreateKudos(kudos *models.Kudos) error {
+	s.kudos = append(s.kudos, kudos)
+	return nil
+}
+
+func (s *MemoryStore) GetKudosByGroup(groupID string) ([]*models.Kudos, error) {
+	var kudos []*models.Kudos
+	for _, given := range s.kudos {
+		if given.GroupID == groupID {
+			kudos = append(kudos, given)
+		}
+	}
+	return kudos, nil
+}
+
+// GetKudosByUser returns the kudos a user gave or received
+func (s *MemoryStore) GetKudosByUser(userID string) ([]*models.Kudos, error) {
+	var kudos []*models.Kudos
+	for _, given := range s.kudos {
+		if given.FromUserID == userID || given.ToUserID == userID {
+			kudos = append(kudos, given)
+		}
+	}
+	return kudos, nil
+}
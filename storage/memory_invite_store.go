@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Invite operations
+func (s *MemoryStore) CreateInvite(invite *models.Invite) error {
+	s.invites[invite.ID] = invite
+	return nil
+}
+
+func (s *MemoryStore) GetInvite(id string) (*models.Invite, error) {
+	if invite, exists := s.invites[id]; exists {
+		return invite, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetInviteByCode(code string) (*models.Invite, error) {
+	for _, invite := range s.invites {
+		if invite.Code == code {
+			return invite, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetInvitesByGroup(groupID string) ([]*models.Invite, error) {
+	var invites []*models.Invite
+	for _, invite := range s.invites {
+		if invite.GroupID == groupID {
+			invites = append(invites, invite)
+		}
+	}
+	sortInvites(invites)
+	return invites, nil
+}
+
+func (s *MemoryStore) GetInvitesForUser(userID string) ([]*models.Invite, error) {
+	var invites []*models.Invite
+	for _, invite := range s.invites {
+		if invite.InviteeID == userID {
+			invites = append(invites, invite)
+		}
+	}
+	sortInvites(invites)
+	return invites, nil
+}
+
+func (s *MemoryStore) UpdateInvite(invite *models.Invite) error {
+	s.invites[invite.ID] = invite
+	return nil
+}
+
+// sortInvites orders invites newest first
+func sortInvites(invites []*models.Invite) {
+	sort.Slice(invites, func(i, j int) bool {
+		return invites[i].CreatedAt.After(invites[j].CreatedAt)
+	})
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// JoinRequest operations
+func (s *MemoryStore) CreateJoinRequest(request *models.JoinRequest) error {
+	s.joinRequests[request.ID] = request
+	return nil
+}
+
+func (s *MemoryStore) GetJoinRequest(id string) (*models.JoinRequest, error) {
+	if request, exists := s.joinRequests[id]; exists {
+		return request, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetJoinRequestsByGroup(groupID string) ([]*models.JoinRequest, error) {
+	var requests []*models.JoinRequest
+	for _, request := range s.joinRequests {
+		if request.GroupID == groupID {
+			requests = append(requests, request)
+		}
+	}
+	sortJoinRequests(requests)
+	return requests, nil
+}
+
+func (s *MemoryStore) GetJoinRequestsByUser(userID string) ([]*models.JoinRequest, error) {
+	var requests []*models.JoinRequest
+	for _, request := range s.joinRequests {
+		if request.UserID == userID {
+			requests = append(requests, request)
+		}
+	}
+	sortJoinRequests(requests)
+	return requests, nil
+}
+
+func (s *MemoryStore) UpdateJoinRequest(request *models.JoinRequest) error {
+	s.joinRequests[request.ID] = request
+	return nil
+}
+
+// sortJoinRequests orders requests oldest first so they are handled in arrival order
+func sortJoinRequests(requests []*models.JoinRequest) {
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+}
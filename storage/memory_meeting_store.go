@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Meeting operations
+func (s *MemoryStore) CreateMeeting(meeting *models.Meeting) error {
+	s.meetings[meeting.ID] = meeting
+	return nil
+}
+
+func (s *MemoryStore) GetMeeting(id string) (*models.Meeting, error) {
+	meeting, exists := s.meetings[id]
+	if !exists {
+		return nil, nil
+	}
+	return meeting, nil
+}
+
+// GetMeetingsByGroup returns a group's meetings, most recent first
+func (s *MemoryStore) GetMeetingsByGroup(groupID string) ([]*models.Meeting, error) {
+	var meetings []*models.Meeting
+	for _, meeting := range s.meetings {
+		if meeting.GroupID == groupID {
+			meetings = append(meetings, meeting)
+		}
+	}
+	sort.Slice(meetings, func(i, j int) bool {
+		if meetings[i].StartedAt.Equal(meetings[j].StartedAt) {
+			return meetings[i].ID > meetings[j].ID
+		}
+		return meetings[i].StartedAt.After(meetings[j].StartedAt)
+	})
+	return meetings, nil
+}
+
+// GetOpenMeeting returns the group's meeting that has not ended yet, or nil
+func (s *MemoryStore) GetOpenMeeting(groupID string) (*models.Meeting, error) {
+	for _, meeting := range s.meetings {
+		if meeting.GroupID == groupID && meeting.EndedAt == nil {
+			return meeting, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) UpdateMeeting(meeting *models.Meeting) error {
+	s.meetings[meeting.ID] = meeting
+	return nil
+}
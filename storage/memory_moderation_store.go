@@ -0,0 +1,13 @@
+package storage
+
+import "allen_hackathon/models"
+
+// Moderation operations
+func (s *MemoryStore) CreateModerationAction(action *models.ModerationAction) error {
+	s.moderationActions = append(s.moderationActions, action)
+	return nil
+}
+
+func (s *MemoryStore) GetModerationActions() ([]*models.ModerationAction, error) {
+	return s.moderationActions, nil
+}
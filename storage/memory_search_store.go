@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// How relevance and activity are blended into the final search score
+const (
+	relevanceBlend = 0.75
+	activityBlend  = 0.25
+)
+
+// SearchGroups ranks joinable groups against a free-text query over titles,
+// descriptions and tags. It applies the same private, archived, dormant,
+// capacity and membership filters as SearchGroupsByTag.
+func (s *MemoryStore) SearchGroups(query string, userID string) []*models.GroupSearchResult {
+	// Search for each word and for its canonical tag when it is a known alias
+	var terms []string
+	for _, term := range tokenize(query) {
+		terms = append(terms, term)
+		if canonical, exists := s.tagSynonyms[term]; exists {
+			for _, canonicalTerm := range tokenize(canonical) {
+				terms = append(terms, canonicalTerm)
+			}
+		}
+	}
+
+	var results []*models.GroupSearchResult
+	maxRelevance, maxActivity := 0.0, 0.0
+	for groupID, relevance := range s.searchIndex.search(terms) {
+		group := s.groups[groupID]
+		if group == nil || group.Private || group.Archived || group.DormantSince != nil || group.Capacity <= len(group.Members) {
+			continue
+		}
+
+		isMember := false
+		for _, memberID := range group.Members {
+			if memberID == userID {
+				isMember = true
+				break
+			}
+		}
+		if isMember {
+			continue
+		}
+
+		results = append(results, &models.GroupSearchResult{Group: group, Relevance: relevance})
+		maxRelevance = max(maxRelevance, relevance)
+		maxActivity = max(maxActivity, float64(group.ActivityScore))
+	}
+
+	for _, result := range results {
+		result.Score = relevanceBlend * result.Relevance / maxRelevance
+		if maxActivity > 0 {
+			result.Score += activityBlend * float64(result.Group.ActivityScore) / maxActivity
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].Group.ID < results[j].Group.ID
+		}
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
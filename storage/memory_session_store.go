@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Study session operations
+func (s *MemoryStore) CreateSession(session *models.StudySession) error {
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *MemoryStore) GetSession(id string) (*models.StudySession, error) {
+	session, exists := s.sessions[id]
+	if !exists {
+		return nil, nil
+	}
+	return session, nil
+}
+
+// GetSessionsByGroup returns a group's sessions, earliest first
+func (s *MemoryStore) GetSessionsByGroup(groupID string) ([]*models.StudySession, error) {
+	var sessions []*models.StudySession
+	for _, session := range s.sessions {
+		if session.GroupID == groupID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Start.Equal(sessions[j].Start) {
+			return sessions[i].ID < sessions[j].ID
+		}
+		return sessions[i].Start.Before(sessions[j].Start)
+	})
+	return sessions, nil
+}
+
+// DeleteSession removes a session together with its RSVPs
+func (s *MemoryStore) DeleteSession(id string) error {
+	delete(s.sessions, id)
+	for key, rsvp := range s.rsvps {
+		if rsvp.SessionID == id {
+			delete(s.rsvps, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) SetSessionRSVP(rsvp *models.SessionRSVP) error {
+	s.rsvps[rsvpKey(rsvp.SessionID, rsvp.UserID)] = rsvp
+	return nil
+}
+
+func (s *MemoryStore) GetSessionRSVP(sessionID string, userID string) (*models.SessionRSVP, error) {
+	rsvp, exists := s.rsvps[rsvpKey(sessionID, userID)]
+	if !exists {
+		return nil, nil
+	}
+	return rsvp, nil
+}
+
+func (s *MemoryStore) GetSessionRSVPs(sessionID string) ([]*models.SessionRSVP, error) {
+	var rsvps []*models.SessionRSVP
+	for _, rsvp := range s.rsvps {
+		if rsvp.SessionID == sessionID {
+			rsvps = append(rsvps, rsvp)
+		}
+	}
+	sortRSVPs(rsvps)
+	return rsvps, nil
+}
+
+func (s *MemoryStore) GetSessionRSVPsByUser(userID string) ([]*models.SessionRSVP, error) {
+	var rsvps []*models.SessionRSVP
+	for _, rsvp := range s.rsvps {
+		if rsvp.UserID == userID {
+			rsvps = append(rsvps, rsvp)
+		}
+	}
+	sortRSVPs(rsvps)
+	return rsvps, nil
+}
+
+func rsvpKey(sessionID string, userID string) string {
+	return sessionID + "/" + userID
+}
+
+func sortRSVPs(rsvps []*models.SessionRSVP) {
+	sort.Slice(rsvps, func(i, j int) bool {
+		if rsvps[i].UpdatedAt.Equal(rsvps[j].UpdatedAt) {
+			return rsvps[i].UserID < rsvps[j].UserID
+		}
+		return rsvps[i].UpdatedAt.Before(rsvps[j].UpdatedAt)
+	})
+}
@@ -11,56 +11,99 @@ import (
 	"github.com/google/uuid"
 )
 
+// answerKey returns the index of a default question's right answer
+func answerKey(option int) *int {
+	return &option
+}
+
 var questions = []models.Question{
 	{
-		ID:        uuid.New().String(),
-		Content:   "What is the value of g (acceleration due to gravity) on Earth?",
-		Options:   []string{"9.8 m/s²", "8.9 m/s²", "10.2 m/s²", "7.8 m/s²"},
-		Timestamp: time.Now(),
+		ID:            uuid.New().String(),
+		Content:       "What is the value of g (acceleration due to gravity) on Earth?",
+		Options:       []string{"8.9 m/s²", "9.8 m/s²", "10.2 m/s²", "7.8 m/s²"},
+		Timestamp:     time.Now(),
+		CorrectOption: answerKey(1),
 	},
 	{
-		ID:        uuid.New().String(),
-		Content:   "Which of these is a noble gas?",
-		Options:   []string{"Helium", "Oxygen", "Nitrogen", "Carbon"},
-		Timestamp: time.Now(),
+		ID:            uuid.New().String(),
+		Content:       "Which of these is a noble gas?",
+		Options:       []string{"Oxygen", "Nitrogen", "Helium", "Carbon"},
+		Timestamp:     time.Now(),
+		CorrectOption: answerKey(2),
 	},
 	{
-		ID:        uuid.New().String(),
-		Content:   "What is the derivative of sin(x)?",
-		Options:   []string{"cos(x)", "-sin(x)", "tan(x)", "-cos(x)"},
-		Timestamp: time.Now(),
+		ID:            uuid.New().String(),
+		Content:       "What is the derivative of sin(x)?",
+		Options:       []string{"-sin(x)", "tan(x)", "-cos(x)", "cos(x)"},
+		Timestamp:     time.Now(),
+		CorrectOption: answerKey(3),
 	},
 	{
-		ID:        uuid.New().String(),
-		Content:   "What is the first law of thermodynamics?",
-		Options:   []string{"Energy cannot be created or destroyed", "Heat flows from hot to cold", "Entropy always increases", "Work equals force times distance"},
-		Timestamp: time.Now(),
+		ID:            uuid.New().String(),
+		Content:       "What is the first law of thermodynamics?",
+		Options:       []string{"Energy cannot be created or destroyed", "Heat flows from hot to cold", "Entropy always increases", "Work equals force times distance"},
+		Timestamp:     time.Now(),
+		CorrectOption: answerKey(0),
 	},
 	{
-		ID:        uuid.New().String(),
-		Content:   "What is the pH of a neutral solution?",
-		Options:   []string{"7", "0", "14", "1"},
-		Timestamp: time.Now(),
+		ID:            uuid.New().String(),
+		Content:       "What is the pH of a neutral solution?",
+		Options:       []string{"0", "14", "7", "1"},
+		Timestamp:     time.Now(),
+		CorrectOption: answerKey(2),
 	},
 }
 
 type MemoryStore struct {
-	users      map[string]*models.User
-	groups     map[string]*models.Group
-	userGroups map[string]*models.UserGroup
-	matches    map[string]*models.UserPair // key: match ID
+	users             map[string]*models.User
+	groups            map[string]*models.Group
+	userGroups        map[string]*models.UserGroup
+	matches           map[string]*models.UserPair // key: match ID
+	invites           map[string]*models.Invite
+	joinRequests      map[string]*models.JoinRequest
+	waitlist          map[string]*models.WaitlistEntry
+	groupChanges      map[string][]*models.GroupChange // key: group ID
+	taxonomy          map[string]*models.TaxonomyNode
+	tagSynonyms       map[string]string // key: alias, value: canonical tag
+	sessions          map[string]*models.StudySession
+	rsvps             map[string]*models.SessionRSVP // key: session ID/user ID
+	meetings          map[string]*models.Meeting
+	attendance        map[string]*models.AttendanceRecord
+	memberships       map[string][]*models.MembershipEvent // key: group ID
+	templates         map[string]*models.GroupTemplate
+	answers           []*models.QuestionAnswer // in the order they were given
+	kudos             []*models.Kudos
+	moderationActions []*models.ModerationAction // in the order they were taken
+	searchIndex       *searchIndex
 }
 
 func NewMemoryStore() *MemoryStore {
 	store := &MemoryStore{
-		users:      make(map[string]*models.User),
-		groups:     make(map[string]*models.Group),
-		userGroups: make(map[string]*models.UserGroup),
-		matches:    make(map[string]*models.UserPair),
+		users:        make(map[string]*models.User),
+		groups:       make(map[string]*models.Group),
+		userGroups:   make(map[string]*models.UserGroup),
+		matches:      make(map[string]*models.UserPair),
+		invites:      make(map[string]*models.Invite),
+		joinRequests: make(map[string]*models.JoinRequest),
+		waitlist:     make(map[string]*models.WaitlistEntry),
+		groupChanges: make(map[string][]*models.GroupChange),
+		taxonomy:     make(map[string]*models.TaxonomyNode),
+		tagSynonyms:  make(map[string]string),
+		sessions:     make(map[string]*models.StudySession),
+		rsvps:        make(map[string]*models.SessionRSVP),
+		meetings:     make(map[string]*models.Meeting),
+		attendance:   make(map[string]*models.AttendanceRecord),
+		memberships:  make(map[string][]*models.MembershipEvent),
+		templates:    make(map[string]*models.GroupTemplate),
+		searchIndex:  newSearchIndex(),
 	}
 
 	// Add dummy questions
 
+	// Add the subject/chapter/topic taxonomy
+	store.seedTaxonomy()
+	store.seedTagSynonyms()
+
 	// Add dummy users
 	dummyUsers := []struct {
 		email    string
@@ -106,6 +149,15 @@ func NewMemoryStore() *MemoryStore {
 		},
 	}
 
+	// Add an admin who manages the taxonomy and other shared settings
+	store.users["admin"] = &models.User{
+		ID:    "admin",
+		Email: "admin@example.com",
+		Score: []models.Score{},
+		Name:  "Admin",
+		Role:  models.RoleAdmin,
+	}
+
 	// Map to store users by email for easy lookup when creating matches
 	usersByEmail := make(map[string]*models.User)
 
@@ -205,7 +257,6 @@ func NewMemoryStore() *MemoryStore {
 				Messages:             []models.Message{},
 				CreateBy:             user1.ID,
 				Capacity:             2,
-				ActivityScore:        int(dm.similarity * 100),
 				RecommendationReason: dm.reason,
 				RecommendationTag:    dm.tag,
 			}
@@ -239,6 +290,7 @@ func NewMemoryStore() *MemoryStore {
 		Title:       fmt.Sprintf("Connect for Thermodynamics"),
 		Description: fmt.Sprintf("Public study group for topic weakness Thermodynamics"),
 		Tag:         "Thermodynamics",
+		Tags:        []string{"physics.thermodynamics"},
 		Type:        "Topic Weakness",
 		Private:     true,
 		Messages: []models.Message{{
@@ -249,7 +301,6 @@ func NewMemoryStore() *MemoryStore {
 		}},
 		CreateBy:             "1",
 		Capacity:             10,
-		ActivityScore:        85,
 		RecommendationReason: "You were matched based on weak performance in Physics",
 		RecommendationTag:    "Weak Performance",
 	}
@@ -264,39 +315,40 @@ func NewMemoryStore() *MemoryStore {
 	subjects := []string{"physics", "chemistry", "maths"}
 	for i, subject := range subjects {
 		group := &models.Group{
-			ID:            "3" + strconv.Itoa(i) + "group",
-			Title:         subject + " Study Group",
-			Description:   "A group for studying " + subject,
-			Members:       []string{},
-			Tag:           subject,
-			Type:          "study",
-			Private:       false,
-			Messages:      []models.Message{},
-			CreateBy:      uuid.New().String(),
-			Capacity:      10,
-			ActivityScore: 100 - (i * 25), // 100, 75, 50
+			ID:          "3" + strconv.Itoa(i) + "group",
+			Title:       subject + " Study Group",
+			Description: "A group for studying " + subject,
+			Members:     []string{},
+			Tag:         subject,
+			Tags:        []string{subject},
+			Type:        "study",
+			Private:     false,
+			Messages:    []models.Message{},
+			CreateBy:    uuid.New().String(),
+			Capacity:    10,
 		}
 		store.groups[group.ID] = group
 	}
 
-	// Add one more physics group with different activity score
+	// Add one more physics group
 	physicsGroup2 := &models.Group{
-		ID:            "41" + "group",
-		Title:         "Advanced Physics Group",
-		Description:   "Advanced physics study group",
-		Members:       []string{},
-		Tag:           "physics",
-		Type:          "study",
-		Private:       false,
-		Messages:      []models.Message{},
-		CreateBy:      uuid.New().String(),
-		Capacity:      10,
-		ActivityScore: 85,
+		ID:          "41" + "group",
+		Title:       "Advanced Physics Group",
+		Description: "Advanced physics study group",
+		Members:     []string{},
+		Tag:         "physics",
+		Tags:        []string{"physics"},
+		Type:        "study",
+		Private:     false,
+		Messages:    []models.Message{},
+		CreateBy:    uuid.New().String(),
+		Capacity:    10,
 	}
 	store.groups[physicsGroup2.ID] = physicsGroup2
 
 	// Add some dummy messages to groups
 	for _, group := range store.groups {
+		group.CreatedAt = time.Now()
 		message := models.Message{
 			ID:        uuid.New().String(),
 			Content:   "Welcome to " + group.Title,
@@ -328,6 +380,11 @@ func NewMemoryStore() *MemoryStore {
 
 	// Generate matches between users based on similar scores
 	store.generateInitialMatches()*/
+
+	// Index the seed groups for full-text search
+	for _, group := range store.groups {
+		store.searchIndex.index(group)
+	}
 	return store
 }
 
@@ -485,7 +542,10 @@ func (s *MemoryStore) DeleteUser(id string) error {
 // Group operations
 func (s *MemoryStore) GetGroup(id string) (*models.Group, error) {
 	if group, exists := s.groups[id]; exists {
-		group.Questions = questions
+		// Groups without their own question set get the default bank
+		if len(group.Questions) == 0 {
+			group.Questions = questions
+		}
 		return group, nil
 	}
 	return nil, nil
@@ -493,16 +553,19 @@ func (s *MemoryStore) GetGroup(id string) (*models.Group, error) {
 
 func (s *MemoryStore) CreateGroup(group *models.Group) error {
 	s.groups[group.ID] = group
+	s.searchIndex.index(group)
 	return nil
 }
 
 func (s *MemoryStore) UpdateGroup(group *models.Group) error {
 	s.groups[group.ID] = group
+	s.searchIndex.index(group)
 	return nil
 }
 
 func (s *MemoryStore) DeleteGroup(id string) error {
 	delete(s.groups, id)
+	s.searchIndex.remove(id)
 	return nil
 }
 
@@ -589,6 +652,14 @@ func (s *MemoryStore) UpdateUserGroup(userGroup *models.UserGroup) error {
 	return nil
 }
 
+func (s *MemoryStore) GetAllUserGroups() ([]*models.UserGroup, error) {
+	userGroups := make([]*models.UserGroup, 0, len(s.userGroups))
+	for _, userGroup := range s.userGroups {
+		userGroups = append(userGroups, userGroup)
+	}
+	return userGroups, nil
+}
+
 func (s *MemoryStore) GetGroupsByIDs(groupIDs []string) ([]*models.Group, error) {
 	var groups []*models.Group
 	for _, id := range groupIDs {
@@ -599,6 +670,41 @@ func (s *MemoryStore) GetGroupsByIDs(groupIDs []string) ([]*models.Group, error)
 	return groups, nil
 }
 
+func (s *MemoryStore) AddGroupChange(change *models.GroupChange) error {
+	s.groupChanges[change.GroupID] = append(s.groupChanges[change.GroupID], change)
+	return nil
+}
+
+// GetGroupChanges returns a group's edit history, oldest first
+func (s *MemoryStore) GetGroupChanges(groupID string) ([]*models.GroupChange, error) {
+	return s.groupChanges[groupID], nil
+}
+
+func (s *MemoryStore) GetAllGroups() ([]*models.Group, error) {
+	groups := make([]*models.Group, 0, len(s.groups))
+	for _, group := range s.groups {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// GetGroupsByParent returns a group's breakout groups, oldest first
+func (s *MemoryStore) GetGroupsByParent(parentID string) ([]*models.Group, error) {
+	groups := []*models.Group{}
+	for _, group := range s.groups {
+		if group.ParentID == parentID {
+			groups = append(groups, group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].CreatedAt.Equal(groups[j].CreatedAt) {
+			return groups[i].ID < groups[j].ID
+		}
+		return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+	})
+	return groups, nil
+}
+
 func (s *MemoryStore) AddActionToGroup(groupID string, action *models.Action) error {
 	group, err := s.GetGroup(groupID)
 	if err != nil {
@@ -612,10 +718,17 @@ func (s *MemoryStore) AddActionToGroup(groupID string, action *models.Action) er
 	return s.UpdateGroup(group)
 }
 
+// SearchGroupsByTag finds joinable groups tagged with the given tag or, when it
+// names a taxonomy node, with any node below it. Tags are compared normalised
+// and aliases are resolved to their canonical tag. Archived and dormant groups
+// are left out.
 func (s *MemoryStore) SearchGroupsByTag(tag string, userID string) []*models.Group {
+	tag = s.resolveTag(tag)
+	nodeIDs, nodeNames := s.expandTag(tag)
+
 	var matchingGroups []*models.Group
 	for _, group := range s.groups {
-		if group != nil && group.Tag == tag && group.Private == false && group.Capacity > len(group.Members) {
+		if group != nil && s.matchesTag(group, tag, nodeIDs, nodeNames) && group.Private == false && !group.Archived && group.DormantSince == nil && group.Capacity > len(group.Members) {
 			// Check if user is not already a member
 			isMember := false
 			for _, memberID := range group.Members {
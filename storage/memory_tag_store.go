@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// seedTagSynonyms adds the common short forms students search with
+func (s *MemoryStore) seedTagSynonyms() {
+	synonyms := map[string][]string{
+		"maths":          {"math", "mathematics"},
+		"physics":        {"phy", "phys"},
+		"chemistry":      {"chem"},
+		"thermodynamics": {"thermo"},
+	}
+	for canonical, aliases := range synonyms {
+		for _, alias := range aliases {
+			s.tagSynonyms[alias] = canonical
+		}
+	}
+}
+
+// Tag synonym operations. Aliases and canonical tags are stored normalised.
+func (s *MemoryStore) SetTagSynonym(synonym *models.TagSynonym) error {
+	s.tagSynonyms[synonym.Alias] = synonym.Canonical
+	return nil
+}
+
+func (s *MemoryStore) GetTagSynonym(alias string) (*models.TagSynonym, error) {
+	if canonical, exists := s.tagSynonyms[alias]; exists {
+		return &models.TagSynonym{Alias: alias, Canonical: canonical}, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetTagSynonyms() ([]*models.TagSynonym, error) {
+	synonyms := make([]*models.TagSynonym, 0, len(s.tagSynonyms))
+	for alias, canonical := range s.tagSynonyms {
+		synonyms = append(synonyms, &models.TagSynonym{Alias: alias, Canonical: canonical})
+	}
+	sort.Slice(synonyms, func(i, j int) bool {
+		if synonyms[i].Canonical == synonyms[j].Canonical {
+			return synonyms[i].Alias < synonyms[j].Alias
+		}
+		return synonyms[i].Canonical < synonyms[j].Canonical
+	})
+	return synonyms, nil
+}
+
+func (s *MemoryStore) DeleteTagSynonym(alias string) error {
+	delete(s.tagSynonyms, alias)
+	return nil
+}
+
+// resolveTag normalises a tag and maps an alias onto its canonical tag, so
+// tags stored before an alias was added still compare equal to it
+func (s *MemoryStore) resolveTag(tag string) string {
+	tag = models.NormalizeTag(tag)
+	if canonical, exists := s.tagSynonyms[tag]; exists {
+		return canonical
+	}
+	return tag
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"allen_hackathon/models"
+)
+
+// seedTaxonomy adds the subject → chapter → topic tree the seed groups are tagged with
+func (s *MemoryStore) seedTaxonomy() {
+	tree := map[string]map[string][]string{
+		"Physics": {
+			"Mechanics":      {"Kinematics", "Laws of Motion"},
+			"Thermodynamics": {"Laws of Thermodynamics", "Heat Transfer"},
+		},
+		"Chemistry": {
+			"Physical Chemistry": {"Chemical Equilibrium", "Acids and Bases"},
+			"Organic Chemistry":  {"Hydrocarbons"},
+		},
+		"Maths": {
+			"Calculus": {"Differentiation", "Integration"},
+			"Algebra":  {"Quadratic Equations"},
+		},
+	}
+
+	for subject, chapters := range tree {
+		subjectNode := &models.TaxonomyNode{ID: TaxonomySlug(subject), Name: subject, Level: models.TaxonomySubject}
+		s.taxonomy[subjectNode.ID] = subjectNode
+		for chapter, topics := range chapters {
+			chapterNode := &models.TaxonomyNode{ID: subjectNode.ID + "." + TaxonomySlug(chapter), Name: chapter, Level: models.TaxonomyChapter, ParentID: subjectNode.ID}
+			s.taxonomy[chapterNode.ID] = chapterNode
+			for _, topic := range topics {
+				topicNode := &models.TaxonomyNode{ID: chapterNode.ID + "." + TaxonomySlug(topic), Name: topic, Level: models.TaxonomyTopic, ParentID: chapterNode.ID}
+				s.taxonomy[topicNode.ID] = topicNode
+			}
+		}
+	}
+}
+
+// TaxonomySlug turns a node name into the lower-case, dash-separated form used in node IDs
+func TaxonomySlug(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// Taxonomy operations
+func (s *MemoryStore) CreateTaxonomyNode(node *models.TaxonomyNode) error {
+	s.taxonomy[node.ID] = node
+	return nil
+}
+
+func (s *MemoryStore) GetTaxonomyNode(id string) (*models.TaxonomyNode, error) {
+	if node, exists := s.taxonomy[id]; exists {
+		return node, nil
+	}
+	return nil, nil
+}
+
+// GetTaxonomyNodes returns every node ordered by ID, which keeps children right after their parent
+func (s *MemoryStore) GetTaxonomyNodes() ([]*models.TaxonomyNode, error) {
+	nodes := make([]*models.TaxonomyNode, 0, len(s.taxonomy))
+	for _, node := range s.taxonomy {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
+func (s *MemoryStore) DeleteTaxonomyNode(id string) error {
+	delete(s.taxonomy, id)
+	return nil
+}
+
+// expandTag resolves a canonical search tag to the taxonomy nodes it names
+// and all of their descendants, returning the matching node IDs and
+// canonical names
+func (s *MemoryStore) expandTag(tag string) (map[string]bool, map[string]bool) {
+	ids := make(map[string]bool)
+	names := make(map[string]bool)
+
+	var roots []string
+	for id, node := range s.taxonomy {
+		if id == tag || s.resolveTag(node.Name) == tag {
+			roots = append(roots, id)
+		}
+	}
+
+	for _, root := range roots {
+		for id, node := range s.taxonomy {
+			if id == root || strings.HasPrefix(id, root+".") {
+				ids[id] = true
+				names[s.resolveTag(node.Name)] = true
+			}
+		}
+	}
+	return ids, names
+}
+
+// matchesTag reports whether the group is tagged with the canonical search
+// tag or with one of the taxonomy nodes below it. The group's own tag is
+// resolved the same way as the search tag.
+func (s *MemoryStore) matchesTag(group *models.Group, tag string, nodeIDs map[string]bool, nodeNames map[string]bool) bool {
+	groupTag := s.resolveTag(group.Tag)
+	if groupTag == tag || nodeNames[groupTag] {
+		return true
+	}
+	for _, groupTag := range group.Tags {
+		if nodeIDs[groupTag] {
+			return true
+		}
+	}
+	return false
+}
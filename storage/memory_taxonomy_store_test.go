@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+
+	"allen_hackathon/models"
+)
+
+// A group tagged before its tag became an alias is still found under the
+// canonical tag, and under the alias
+func TestSearchGroupsByTagResolvesStoredAliases(t *testing.T) {
+	store := NewMemoryStore()
+	group := &models.Group{ID: "quantum", Title: "Quantum", Tag: "Quantum", Members: []string{"1"}, Capacity: 10}
+	if err := store.CreateGroup(group); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	canonical := models.NormalizeTag("Quantum Mechanics")
+	if err := store.SetTagSynonym(&models.TagSynonym{Alias: models.NormalizeTag("Quantum"), Canonical: canonical}); err != nil {
+		t.Fatalf("SetTagSynonym: %v", err)
+	}
+
+	for _, tag := range []string{"Quantum Mechanics", "quantum"} {
+		t.Run(tag, func(t *testing.T) {
+			found := false
+			for _, match := range store.SearchGroupsByTag(tag, "2") {
+				found = found || match.ID == group.ID
+			}
+			if !found {
+				t.Fatalf("searching %q did not find the group tagged %q", tag, group.Tag)
+			}
+		})
+	}
+}
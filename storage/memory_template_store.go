@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Group template operations
+func (s *MemoryStore) CreateTemplate(template *models.GroupTemplate) error {
+	s.templates[template.ID] = template
+	return nil
+}
+
+func (s *MemoryStore) GetTemplate(id string) (*models.GroupTemplate, error) {
+	template, exists := s.templates[id]
+	if !exists {
+		return nil, nil
+	}
+	return template, nil
+}
+
+// GetTemplates returns every template, oldest first
+func (s *MemoryStore) GetTemplates() ([]*models.GroupTemplate, error) {
+	templates := make([]*models.GroupTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].CreatedAt.Equal(templates[j].CreatedAt) {
+			return templates[i].ID < templates[j].ID
+		}
+		return templates[i].CreatedAt.Before(templates[j].CreatedAt)
+	})
+	return templates, nil
+}
+
+func (s *MemoryStore) DeleteTemplate(id string) error {
+	delete(s.templates, id)
+	return nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"sort"
+
+	"allen_hackathon/models"
+)
+
+// Waitlist operations
+func (s *MemoryStore) CreateWaitlistEntry(entry *models.WaitlistEntry) error {
+	s.waitlist[entry.ID] = entry
+	return nil
+}
+
+// GetWaitlistByGroup returns a group's waitlist in queue order
+func (s *MemoryStore) GetWaitlistByGroup(groupID string) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	for _, entry := range s.waitlist {
+		if entry.GroupID == groupID {
+			entries = append(entries, entry)
+		}
+	}
+	sortWaitlist(entries)
+	return entries, nil
+}
+
+func (s *MemoryStore) GetWaitlistByUser(userID string) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	for _, entry := range s.waitlist {
+		if entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+	sortWaitlist(entries)
+	return entries, nil
+}
+
+func (s *MemoryStore) UpdateWaitlistEntry(entry *models.WaitlistEntry) error {
+	s.waitlist[entry.ID] = entry
+	return nil
+}
+
+// sortWaitlist orders entries first come, first served
+func sortWaitlist(entries []*models.WaitlistEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].JoinedAt.Equal(entries[j].JoinedAt) {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].JoinedAt.Before(entries[j].JoinedAt)
+	})
+}
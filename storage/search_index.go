@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"allen_hackathon/models"
+)
+
+// Field weights: a word in the title counts for more than one in the description
+const (
+	titleWeight       = 3
+	tagWeight         = 2
+	descriptionWeight = 1
+)
+
+// How much a prefix or fuzzy match is worth compared to an exact term match
+const (
+	prefixMatchWeight = 0.7
+	fuzzyMatchWeight  = 0.5
+	minPrefixLength   = 2
+)
+
+// BM25 tuning parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// searchIndex is an inverted index over group titles, descriptions and tags
+type searchIndex struct {
+	postings    map[string]map[string]int // term → group ID → weighted term frequency
+	docTerms    map[string]map[string]int // group ID → term → weighted term frequency
+	docLength   map[string]int
+	totalLength int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings:  make(map[string]map[string]int),
+		docTerms:  make(map[string]map[string]int),
+		docLength: make(map[string]int),
+	}
+}
+
+// tokenize splits normalised text into words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(models.NormalizeTag(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// index adds or replaces a group in the index
+func (idx *searchIndex) index(group *models.Group) {
+	idx.remove(group.ID)
+
+	terms := make(map[string]int)
+	add := func(text string, weight int) {
+		for _, term := range tokenize(text) {
+			terms[term] += weight
+		}
+	}
+	add(group.Title, titleWeight)
+	add(group.Description, descriptionWeight)
+	add(group.Tag, tagWeight)
+	for _, tag := range group.Tags {
+		add(tag, tagWeight)
+	}
+
+	length := 0
+	for term, frequency := range terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[string]int)
+		}
+		idx.postings[term][group.ID] = frequency
+		length += frequency
+	}
+	idx.docTerms[group.ID] = terms
+	idx.docLength[group.ID] = length
+	idx.totalLength += length
+}
+
+func (idx *searchIndex) remove(groupID string) {
+	for term := range idx.docTerms[groupID] {
+		delete(idx.postings[term], groupID)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= idx.docLength[groupID]
+	delete(idx.docTerms, groupID)
+	delete(idx.docLength, groupID)
+}
+
+// search scores every group matching at least one query term with BM25. Each
+// query term may match indexed terms exactly, by prefix or within a small
+// edit distance, and the best of those matches counts for each group.
+func (idx *searchIndex) search(terms []string) map[string]float64 {
+	scores := make(map[string]float64)
+	if len(idx.docLength) == 0 {
+		return scores
+	}
+	docCount := float64(len(idx.docLength))
+	avgLength := float64(idx.totalLength) / docCount
+
+	for _, queryTerm := range terms {
+		best := make(map[string]float64)
+		for term, postings := range idx.postings {
+			weight := matchWeight(queryTerm, term)
+			if weight == 0 {
+				continue
+			}
+			idf := math.Log(1 + (docCount-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+			for groupID, frequency := range postings {
+				tf := float64(frequency)
+				norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(idx.docLength[groupID])/avgLength))
+				if score := weight * idf * norm; score > best[groupID] {
+					best[groupID] = score
+				}
+			}
+		}
+		for groupID, score := range best {
+			scores[groupID] += score
+		}
+	}
+	return scores
+}
+
+// matchWeight rates how well an indexed term matches a query term, or returns 0
+func matchWeight(queryTerm string, term string) float64 {
+	if term == queryTerm {
+		return 1
+	}
+	if len(queryTerm) >= minPrefixLength && strings.HasPrefix(term, queryTerm) {
+		return prefixMatchWeight
+	}
+	if maxEdits := allowedEdits(queryTerm); maxEdits > 0 && withinEditDistance(queryTerm, term, maxEdits) {
+		return fuzzyMatchWeight
+	}
+	return 0
+}
+
+// allowedEdits tolerates more typos in longer words
+func allowedEdits(term string) int {
+	switch length := len([]rune(term)); {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// withinEditDistance reports whether the Levenshtein distance between a and b is at most max
+func withinEditDistance(a string, b string, max int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return false
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if rowMin > max {
+			return false
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)] <= max
+}
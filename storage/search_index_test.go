@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"allen_hackathon/models"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Advanced Physics", "advanced physics"},
+		{"  Thermo-dynamics, heat & work!  ", "thermo dynamics heat work"},
+		{"Électricité", "electricite"},
+		{"Ｐｈｙｓｉｃｓ 101", "physics 101"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := strings.Join(tokenize(tt.text), " "); got != tt.want {
+				t.Fatalf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithinEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want bool
+	}{
+		{"physics", "physics", 0, true},
+		{"physics", "physcs", 1, true},
+		{"physics", "phsyics", 1, false},
+		{"physics", "phsyics", 2, true},
+		{"chemistry", "chemestry", 1, true},
+		{"chemistry", "chem", 2, false},
+		{"algebra", "algebras", 1, true},
+		{"", "ab", 2, true},
+		{"", "abc", 2, false},
+		{"électron", "electron", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := withinEditDistance(tt.a, tt.b, tt.max); got != tt.want {
+				t.Fatalf("withinEditDistance(%q, %q, %d) = %v, want %v", tt.a, tt.b, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		queryTerm  string
+		term       string
+		wantWeight float64
+	}{
+		{"exact", "physics", "physics", 1},
+		{"prefix", "phys", "physics", prefixMatchWeight},
+		{"prefix too short", "p", "physics", 0},
+		{"one typo", "physcs", "physics", fuzzyMatchWeight},
+		{"two typos in a long word", "thermodinamcs", "thermodynamics", fuzzyMatchWeight},
+		{"two typos in a short word", "phsycs", "physics", 0},
+		{"no typos allowed in short words", "cel", "cell", prefixMatchWeight},
+		{"short word typo", "bio", "bia", 0},
+		{"unrelated", "algebra", "physics", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchWeight(tt.queryTerm, tt.term); got != tt.wantWeight {
+				t.Fatalf("matchWeight(%q, %q) = %v, want %v", tt.queryTerm, tt.term, got, tt.wantWeight)
+			}
+		})
+	}
+}
+
+func newTestIndex(groups ...*models.Group) *searchIndex {
+	idx := newSearchIndex()
+	for _, group := range groups {
+		idx.index(group)
+	}
+	return idx
+}
+
+// rankedIDs returns the matching group IDs, best first
+func rankedIDs(scores map[string]float64) []string {
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] == scores[ids[j]] {
+			return ids[i] < ids[j]
+		}
+		return scores[ids[i]] > scores[ids[j]]
+	})
+	return ids
+}
+
+func TestSearchIndexRanking(t *testing.T) {
+	idx := newTestIndex(
+		&models.Group{ID: "title", Title: "Thermodynamics", Description: "Heat engines and entropy"},
+		&models.Group{ID: "description", Title: "Physics", Description: "We cover thermodynamics and optics"},
+		&models.Group{ID: "tag", Title: "Evening revision", Tag: "thermodynamics"},
+		&models.Group{ID: "other", Title: "Organic chemistry", Description: "Reactions and mechanisms"},
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"title beats tag beats description", "thermodynamics", []string{"title", "tag", "description"}},
+		{"prefix", "thermo", []string{"title", "tag", "description"}},
+		{"typo", "thermodinamics", []string{"title", "tag", "description"}},
+		{"several terms add up", "physics optics", []string{"description"}},
+		{"no match", "calculus", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankedIDs(idx.search(tokenize(tt.query)))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIndexExactBeatsFuzzy(t *testing.T) {
+	idx := newTestIndex(
+		&models.Group{ID: "exact", Title: "Physics"},
+		&models.Group{ID: "prefix", Title: "Physicsathon"},
+		&models.Group{ID: "fuzzy", Title: "Physica"},
+	)
+	got := rankedIDs(idx.search([]string{"physics"}))
+	if strings.Join(got, ",") != "exact,prefix,fuzzy" {
+		t.Fatalf("ranking = %v, want exact,prefix,fuzzy", got)
+	}
+}
+
+func TestSearchIndexReindexAndRemove(t *testing.T) {
+	group := &models.Group{ID: "g1", Title: "Algebra", Tags: []string{"maths"}}
+	idx := newTestIndex(group, &models.Group{ID: "g2", Title: "Geometry"})
+	lengthBefore := idx.totalLength
+
+	group.Title = "Calculus"
+	idx.index(group)
+	if scores := idx.search([]string{"algebra"}); len(scores) != 0 {
+		t.Fatalf("old title still matches after reindexing: %v", scores)
+	}
+	if scores := idx.search([]string{"calculus"}); scores["g1"] == 0 {
+		t.Fatalf("new title does not match after reindexing")
+	}
+	if idx.totalLength != lengthBefore {
+		t.Fatalf("total length = %d after reindexing, want %d", idx.totalLength, lengthBefore)
+	}
+
+	idx.remove("g1")
+	if scores := idx.search([]string{"maths"}); len(scores) != 0 {
+		t.Fatalf("removed group still matches: %v", scores)
+	}
+	if _, exists := idx.postings["calculus"]; exists {
+		t.Fatalf("postings for a removed group's terms were kept")
+	}
+	if idx.totalLength != idx.docLength["g2"] {
+		t.Fatalf("total length = %d, want %d", idx.totalLength, idx.docLength["g2"])
+	}
+}
+
+func TestSearchIndexEmpty(t *testing.T) {
+	if scores := newSearchIndex().search([]string{"physics"}); len(scores) != 0 {
+		t.Fatalf("empty index returned %v", scores)
+	}
+}
@@ -21,13 +21,97 @@ type Store interface {
 	RemoveMemberFromGroup(groupID string, userID string) error
 	AddMessageToGroup(groupID string, message *models.Message) error
 	GetGroupsByIDs(groupIDs []string) ([]*models.Group, error)
+	GetAllGroups() ([]*models.Group, error)
+	GetGroupsByParent(parentID string) ([]*models.Group, error)
 	AddActionToGroup(groupID string, action *models.Action) error
 	SearchGroupsByTag(tag string, userID string) []*models.Group
+	SearchGroups(query string, userID string) []*models.GroupSearchResult
+	AddGroupChange(change *models.GroupChange) error
+	GetGroupChanges(groupID string) ([]*models.GroupChange, error)
 
 	// UserGroup operations
 	GetUserGroup(userID string) (*models.UserGroup, error)
 	CreateUserGroup(userGroup *models.UserGroup) error
 	UpdateUserGroup(userGroup *models.UserGroup) error
+	GetAllUserGroups() ([]*models.UserGroup, error)
+
+	// Invite operations
+	CreateInvite(invite *models.Invite) error
+	GetInvite(id string) (*models.Invite, error)
+	GetInviteByCode(code string) (*models.Invite, error)
+	GetInvitesByGroup(groupID string) ([]*models.Invite, error)
+	GetInvitesForUser(userID string) ([]*models.Invite, error)
+	UpdateInvite(invite *models.Invite) error
+
+	// JoinRequest operations
+	CreateJoinRequest(request *models.JoinRequest) error
+	GetJoinRequest(id string) (*models.JoinRequest, error)
+	GetJoinRequestsByGroup(groupID string) ([]*models.JoinRequest, error)
+	GetJoinRequestsByUser(userID string) ([]*models.JoinRequest, error)
+	UpdateJoinRequest(request *models.JoinRequest) error
+
+	// Waitlist operations
+	CreateWaitlistEntry(entry *models.WaitlistEntry) error
+	GetWaitlistByGroup(groupID string) ([]*models.WaitlistEntry, error)
+	GetWaitlistByUser(userID string) ([]*models.WaitlistEntry, error)
+	UpdateWaitlistEntry(entry *models.WaitlistEntry) error
+
+	// Taxonomy operations
+	CreateTaxonomyNode(node *models.TaxonomyNode) error
+	GetTaxonomyNode(id string) (*models.TaxonomyNode, error)
+	GetTaxonomyNodes() ([]*models.TaxonomyNode, error)
+	DeleteTaxonomyNode(id string) error
+
+	// Tag synonym operations
+	SetTagSynonym(synonym *models.TagSynonym) error
+	GetTagSynonym(alias string) (*models.TagSynonym, error)
+	GetTagSynonyms() ([]*models.TagSynonym, error)
+	DeleteTagSynonym(alias string) error
+
+	// Study session operations
+	CreateSession(session *models.StudySession) error
+	GetSession(id string) (*models.StudySession, error)
+	GetSessionsByGroup(groupID string) ([]*models.StudySession, error)
+	DeleteSession(id string) error
+	SetSessionRSVP(rsvp *models.SessionRSVP) error
+	GetSessionRSVP(sessionID string, userID string) (*models.SessionRSVP, error)
+	GetSessionRSVPs(sessionID string) ([]*models.SessionRSVP, error)
+	GetSessionRSVPsByUser(userID string) ([]*models.SessionRSVP, error)
+
+	// Meeting operations
+	CreateMeeting(meeting *models.Meeting) error
+	GetMeeting(id string) (*models.Meeting, error)
+	GetMeetingsByGroup(groupID string) ([]*models.Meeting, error)
+	GetOpenMeeting(groupID string) (*models.Meeting, error)
+	UpdateMeeting(meeting *models.Meeting) error
+
+	// Attendance operations
+	CreateAttendanceRecord(record *models.AttendanceRecord) error
+	GetAttendanceByGroup(groupID string) ([]*models.AttendanceRecord, error)
+	GetAttendanceByUser(userID string) ([]*models.AttendanceRecord, error)
+
+	// Membership event operations
+	AddMembershipEvent(event *models.MembershipEvent) error
+	GetMembershipEvents(groupID string) ([]*models.MembershipEvent, error)
+
+	// Group template operations
+	CreateTemplate(template *models.GroupTemplate) error
+	GetTemplate(id string) (*models.GroupTemplate, error)
+	GetTemplates() ([]*models.GroupTemplate, error)
+	DeleteTemplate(id string) error
+
+	// Contribution operations
+	CreateQuestionAnswer(answer *models.QuestionAnswer) error
+	GetQuestionAnswer(groupID string, questionID string, userID string) (*models.QuestionAnswer, error)
+	GetQuestionAnswersByGroup(groupID string) ([]*models.QuestionAnswer, error)
+	GetQuestionAnswersByUser(userID string) ([]*models.QuestionAnswer, error)
+	CreateKudos(kudos *models.Kudos) error
+	GetKudosByGroup(groupID string) ([]*models.Kudos, error)
+	GetKudosByUser(userID string) ([]*models.Kudos, error)
+
+	// Moderation operations
+	CreateModerationAction(action *models.ModerationAction) error
+	GetModerationActions() ([]*models.ModerationAction, error)
 
 	// Match operations
 	GetMatches(userID string) []*models.UserPair